@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/git"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/section"
+)
+
+var (
+	explainSection string
+	explainIssues  bool
+)
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Work with the filters in your configuration",
+}
+
+var filterFmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Rewrite the filters in your configuration file in canonical form",
+	Long: `Rewrite the section filters and named filters in your configuration file in canonical form.
+The file is updated in place. Filters that aren't valid DSL are left untouched and reported.`,
+	Example: `
+# Format the filters in the configuration gh dash would use
+gh dash filter fmt
+
+# Format the filters in a specific configuration file
+gh dash filter fmt --config /path/to/configuration/file.yml
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfgPath, err := config.ConfigPath(config.Location{RepoPath: repoInPwd(), ConfigFlag: cfgFlag})
+		if err != nil {
+			return err
+		}
+		result, err := config.FormatFilters(cfgPath)
+		if err != nil {
+			return err
+		}
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(cmd.ErrOrStderr(), "skipped %s\n", skipped.Error())
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "formatted %d filters in %s\n", result.Changed, cfgPath)
+		return nil
+	},
+}
+
+var filterExplainCmd = &cobra.Command{
+	Use:   "explain [filter]",
+	Short: "Show how a filter is parsed and what each provider is asked for",
+	Long: `Print the parsed filter, its normalized form and, for each enabled provider instance,
+the GitHub search query or GitLab request it translates to, the part that is filtered locally,
+and whether the filter's provider predicates include or exclude the instance.`,
+	Example: `
+# Explain a filter as a pull request section would use it
+gh dash filter explain 'state = "open" and author = "me"'
+
+# Explain the filter of an issues section from your configuration
+gh dash filter explain --issues --section "Assigned"
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.ParseConfig(config.Location{RepoPath: repoInPwd(), ConfigFlag: cfgFlag})
+		if err != nil {
+			return err
+		}
+
+		view := config.PRsView
+		limit := cfg.Defaults.PrsLimit
+		if explainIssues {
+			view = config.IssuesView
+			limit = cfg.Defaults.IssuesLimit
+		}
+
+		var filters string
+		switch {
+		case len(args) == 1 && explainSection != "":
+			return fmt.Errorf("pass either a filter or --section, not both")
+		case len(args) == 1:
+			filters = args[0]
+		case explainSection != "":
+			filters, limit, err = sectionFilters(cfg, view, explainSection, limit)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("pass a filter or --section")
+		}
+
+		instances := providers.DiscoverInstances(cfg.Providers.Include, cfg.Providers.Exclude)
+		explanation := section.ExplainFilter(filters, cfg.Filters, view, instances, limit)
+		fmt.Fprint(cmd.OutOrStdout(), explanation.String())
+		return nil
+	},
+}
+
+func sectionFilters(cfg config.Config, view config.ViewType, title string, limit int) (string, int, error) {
+	if view == config.IssuesView {
+		for _, sectionCfg := range cfg.IssuesSections {
+			if strings.EqualFold(sectionCfg.Title, title) {
+				if sectionCfg.Limit != nil {
+					limit = *sectionCfg.Limit
+				}
+				return sectionCfg.Filters, limit, nil
+			}
+		}
+		return "", 0, fmt.Errorf("no issues section titled %q", title)
+	}
+	for _, sectionCfg := range cfg.PRSections {
+		if strings.EqualFold(sectionCfg.Title, title) {
+			if sectionCfg.Limit != nil {
+				limit = *sectionCfg.Limit
+			}
+			return sectionCfg.Filters, limit, nil
+		}
+	}
+	return "", 0, fmt.Errorf("no pull request section titled %q", title)
+}
+
+func repoInPwd() string {
+	repo, err := git.GetRepoInPwd()
+	if err != nil || repo == nil {
+		return ""
+	}
+	return repo.Path()
+}
+
+func init() {
+	filterExplainCmd.Flags().StringVar(
+		&explainSection,
+		"section",
+		"",
+		"explain the filter of the section with this title",
+	)
+	filterExplainCmd.Flags().BoolVar(
+		&explainIssues,
+		"issues",
+		false,
+		"explain the filter as an issues section would use it",
+	)
+	filterCmd.AddCommand(filterFmtCmd)
+	filterCmd.AddCommand(filterExplainCmd)
+	rootCmd.AddCommand(filterCmd)
+}
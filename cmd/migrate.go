@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+)
+
+var migrateDryRun bool
+
+var migrateConfigCmd = &cobra.Command{
+	Use:   "migrate-config",
+	Short: "Rewrite legacy GitHub search filters in your configuration file to the DSL",
+	Long: `Rewrite the section filters in your configuration file that still use GitHub search qualifiers,
+like "is:open author:@me", to the filter DSL. The original file is kept with a .bak suffix.
+Qualifiers without a DSL equivalent are dropped, reported and noted in a comment next to the filter.`,
+	Example: `
+# Show what would change without writing anything
+gh dash migrate-config --dry-run
+
+# Migrate a specific configuration file
+gh dash migrate-config --config /path/to/configuration/file.yml
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfgPath, err := config.ConfigPath(config.Location{RepoPath: repoInPwd(), ConfigFlag: cfgFlag})
+		if err != nil {
+			return err
+		}
+		result, err := config.MigrateFilters(cfgPath, migrateDryRun)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(result.Migrations) == 0 {
+			fmt.Fprintf(out, "no legacy filters found in %s\n", cfgPath)
+			return nil
+		}
+		for _, migration := range result.Migrations {
+			fmt.Fprintf(out, "%s\n  - %s\n  + %s\n", migration.Location, migration.From, migration.To)
+			for _, skipped := range migration.Skipped {
+				fmt.Fprintf(out, "  ! %s\n", skipped.Error())
+			}
+		}
+		if migrateDryRun {
+			fmt.Fprintf(out, "\ndry run, %s was not changed\n", cfgPath)
+			return nil
+		}
+		fmt.Fprintf(out, "\nmigrated %d filters in %s, the original is at %s\n",
+			len(result.Migrations), cfgPath, result.BackupPath)
+		return nil
+	},
+}
+
+func init() {
+	migrateConfigCmd.Flags().BoolVar(
+		&migrateDryRun,
+		"dry-run",
+		false,
+		"print the migrated filters without changing the configuration file",
+	)
+	rootCmd.AddCommand(migrateConfigCmd)
+}
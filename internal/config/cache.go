@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const DEFAULT_XDG_CACHE_DIRNAME = ".cache"
+
+// CacheDir returns the directory gh-dash keeps its cached data in.
+func CacheDir() (string, error) {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(homeDir, DEFAULT_XDG_CACHE_DIRNAME)
+	}
+	return filepath.Join(cacheDir, DashDir), nil
+}
+
+// WriteCacheFile replaces the file at path in one rename, so a crash or a
+// concurrent launch never sees half of it.
+func WriteCacheFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+)
+
+type FormatResult struct {
+	Changed int
+	Skipped []FilterFormatError
+}
+
+// FilterFormatError describes a filter FormatFilters left alone because it
+// isn't valid DSL.
+type FilterFormatError struct {
+	Location string
+	Err      error
+}
+
+func (err FilterFormatError) Error() string {
+	return fmt.Sprintf("%s: %v", err.Location, err.Err)
+}
+
+// FormatFilters rewrites the section filters and named filters in the config
+// file at cfgPath into their canonical DSL form. Comments and the rest of the
+// file are kept.
+func FormatFilters(cfgPath string) (FormatResult, error) {
+	var result FormatResult
+	file, err := readConfigDocument(cfgPath)
+	if err != nil {
+		return result, err
+	}
+
+	filters := append(sectionFilterNodes(file.root), namedFilterNodes(file.root)...)
+	for _, filter := range filters {
+		formatted, err := dsl.FormatFilter(filter.node.Value)
+		if err != nil {
+			result.Skipped = append(result.Skipped, FilterFormatError{Location: filter.location, Err: err})
+			continue
+		}
+		if formatted != filter.node.Value {
+			filter.node.Value = formatted
+			result.Changed++
+		}
+	}
+
+	if result.Changed == 0 {
+		return result, nil
+	}
+	return result, file.write(cfgPath, filters)
+}
+
+type configDocument struct {
+	content []byte
+	mode    os.FileMode
+	doc     yaml.Node
+	root    *yaml.Node
+}
+
+func readConfigDocument(cfgPath string) (configDocument, error) {
+	var file configDocument
+	info, err := os.Stat(cfgPath)
+	if err != nil {
+		return file, err
+	}
+	file.mode = info.Mode()
+	file.content, err = os.ReadFile(cfgPath)
+	if err != nil {
+		return file, err
+	}
+	if err := yaml.Unmarshal(file.content, &file.doc); err != nil {
+		return file, parsingError{path: cfgPath, err: err}
+	}
+	if len(file.doc.Content) > 0 && file.doc.Content[0].Kind == yaml.MappingNode {
+		file.root = file.doc.Content[0]
+	}
+	return file, nil
+}
+
+// write saves the config with the changed filters spliced into the original
+// content, so the rest of the file stays byte for byte as the user wrote it.
+func (file *configDocument) write(cfgPath string, filters []filterNode) error {
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+	for _, filter := range filters {
+		if filter.node.Value == filter.value && filter.node.LineComment == filter.comment {
+			continue
+		}
+		start, end := file.scalarSpan(filter)
+		text, err := renderScalar(filter)
+		if err != nil {
+			return err
+		}
+		edits = append(edits, edit{start: start, end: end, text: text})
+	}
+	// splice from the end so the offsets of earlier edits stay valid
+	slices.SortFunc(edits, func(a, b edit) int { return b.start - a.start })
+	content := slices.Clone(file.content)
+	for _, edit := range edits {
+		content = slices.Concat(content[:edit.start], []byte(edit.text), content[edit.end:])
+	}
+	return os.WriteFile(cfgPath, content, file.mode)
+}
+
+// scalarSpan returns the byte range of the filter's scalar in the original
+// content, including its line comment.
+func (file *configDocument) scalarSpan(filter filterNode) (int, int) {
+	content := file.content
+	start := file.offset(filter.node.Line, filter.node.Column)
+	indent := filter.key.Column - 1
+	end := start
+	switch filter.node.Style {
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		// the header and its comment, then every blank or more indented line
+		end = lineEnd(content, start)
+		for next := end + 1; next < len(content); next = lineEnd(content, next) + 1 {
+			line := content[next:lineEnd(content, next)]
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			if lineIndent(line) <= indent {
+				break
+			}
+			end = next + len(bytes.TrimRight(line, " \t\r"))
+		}
+		return start, end
+	case yaml.DoubleQuotedStyle:
+		for end = start + 1; end < len(content) && content[end] != '"'; end++ {
+			if content[end] == '\\' {
+				end++
+			}
+		}
+		end++
+	case yaml.SingleQuotedStyle:
+		for end = start + 1; end < len(content); end++ {
+			if content[end] == '\'' {
+				if end+1 < len(content) && content[end+1] == '\'' {
+					end++
+					continue
+				}
+				break
+			}
+		}
+		end++
+	default:
+		// a plain scalar continues on the following more indented lines
+		end = start + len(plainValue(content[start:lineEnd(content, start)]))
+		for next := lineEnd(content, start) + 1; next < len(content); next = lineEnd(content, next) + 1 {
+			line := content[next:lineEnd(content, next)]
+			trimmed := bytes.TrimSpace(line)
+			if len(trimmed) == 0 {
+				continue
+			}
+			if lineIndent(line) <= indent || trimmed[0] == '#' {
+				break
+			}
+			end = next + len(plainValue(line))
+		}
+	}
+	end = min(end, len(content))
+	if filter.comment != "" {
+		rest := content[end:lineEnd(content, end)]
+		if trimmed := bytes.TrimLeft(rest, " \t"); len(trimmed) > 0 && trimmed[0] == '#' {
+			end += len(bytes.TrimRight(rest, " \t\r"))
+		}
+	}
+	return start, end
+}
+
+// offset converts a node's line and column, which counts characters, to a
+// byte offset in the content.
+func (file *configDocument) offset(line, column int) int {
+	offset := 0
+	for range line - 1 {
+		offset = lineEnd(file.content, offset) + 1
+	}
+	for range column - 1 {
+		_, size := utf8.DecodeRune(file.content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// renderScalar encodes the filter's scalar in its original style, indented
+// to sit after its key.
+func renderScalar(filter filterNode) (string, error) {
+	value := &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Style:       filter.node.Style,
+		Tag:         filter.node.Tag,
+		Value:       filter.node.Value,
+		LineComment: filter.node.LineComment,
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "k"}, value},
+	}); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSuffix(strings.TrimPrefix(buf.String(), "k: "), "\n"), "\n")
+	padding := strings.Repeat(" ", filter.key.Column-1)
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = padding + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func lineEnd(content []byte, offset int) int {
+	if i := bytes.IndexByte(content[offset:], '\n'); i >= 0 {
+		return offset + i
+	}
+	return len(content)
+}
+
+func lineIndent(line []byte) int {
+	return len(line) - len(bytes.TrimLeft(line, " "))
+}
+
+// plainValue returns the part of a line of a plain scalar before its
+// comment, without the surrounding whitespace the scalar doesn't include.
+func plainValue(line []byte) []byte {
+	for i := 1; i < len(line); i++ {
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			line = line[:i]
+			break
+		}
+	}
+	return bytes.TrimRight(line, " \t\r")
+}
+
+// filterNode is a filter scalar of the config with the value and comment it
+// was read with, to tell whether it changed.
+type filterNode struct {
+	location string
+	key      *yaml.Node
+	node     *yaml.Node
+	value    string
+	comment  string
+}
+
+func newFilterNode(location string, key, node *yaml.Node) filterNode {
+	return filterNode{location: location, key: key, node: node, value: node.Value, comment: node.LineComment}
+}
+
+// sectionFilterNodes returns the `filters` scalar of every PR and issue
+// section, labelled with the section's index and title.
+func sectionFilterNodes(root *yaml.Node) []filterNode {
+	var filters []filterNode
+	for _, key := range []string{"prSections", "issuesSections"} {
+		sections := mappingValue(root, key)
+		if sections == nil {
+			continue
+		}
+		for i, section := range sections.Content {
+			filtersKey, node := mappingEntry(section, "filters")
+			if node == nil || node.Kind != yaml.ScalarNode {
+				continue
+			}
+			location := fmt.Sprintf("%s[%d]", key, i)
+			if title := mappingValue(section, "title"); title != nil {
+				location = fmt.Sprintf("%s %q", location, title.Value)
+			}
+			filters = append(filters, newFilterNode(location, filtersKey, node))
+		}
+	}
+	return filters
+}
+
+// namedFilterNodes returns the definitions in the top-level `filters` map.
+func namedFilterNodes(root *yaml.Node) []filterNode {
+	named := mappingValue(root, "filters")
+	if named == nil || named.Kind != yaml.MappingNode {
+		return nil
+	}
+	var filters []filterNode
+	for i := 0; i+1 < len(named.Content); i += 2 {
+		if named.Content[i+1].Kind != yaml.ScalarNode {
+			continue
+		}
+		location := fmt.Sprintf("filters.%s", named.Content[i].Value)
+		filters = append(filters, newFilterNode(location, named.Content[i], named.Content[i+1]))
+	}
+	return filters
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, value := mappingEntry(node, key)
+	return value
+}
+
+// mappingEntry returns the key and value nodes of key in the mapping node.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
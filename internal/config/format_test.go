@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFilters(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `# my dashboard
+filters:
+  mine: author="me"   OR assignee = "me"
+prSections:
+  - title: Mine
+    # only open ones
+    filters: >-
+      state = "open"
+      AND @mine
+  - title: Legacy
+    filters: is:open author:@me
+issuesSections:
+  - title: Bugs
+    filters: 'label in ["bug"]'
+pager:
+  diff: less
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(content), 0o600))
+
+	result, err := FormatFilters(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Changed)
+	require.Len(t, result.Skipped, 1)
+	require.Equal(t, `prSections[1] "Legacy"`, result.Skipped[0].Location)
+
+	formatted, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, `# my dashboard
+filters:
+  mine: author = "me" or assignee = "me"
+prSections:
+  - title: Mine
+    # only open ones
+    filters: >-
+      state = "open" and @mine
+  - title: Legacy
+    filters: is:open author:@me
+issuesSections:
+  - title: Bugs
+    filters: 'label in ["bug"]'
+pager:
+  diff: less
+`, string(formatted))
+
+	info, err := os.Stat(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestFormatFiltersKeepsTheFileLayout(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `filters:
+    mine:   "author=\"me\""   # mine
+    théirs: 'author  =  "them"'
+prSections:
+-   title: "Mine"
+    filters: state="open"
+      AND @mine
+    layout: {author: {width: 10}}
+
+
+-   title: Theirs
+    filters: |
+        @théirs
+pager: {diff: less}
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(content), 0o600))
+
+	result, err := FormatFilters(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.Changed)
+
+	formatted, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, `filters:
+    mine:   "author = \"me\"" # mine
+    théirs: 'author = "them"'
+prSections:
+-   title: "Mine"
+    filters: state = "open" and @mine
+    layout: {author: {width: 10}}
+
+
+-   title: Theirs
+    filters: |-
+      @théirs
+pager: {diff: less}
+`, string(formatted))
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+)
+
+type MigrateResult struct {
+	Migrations []FilterMigration
+	BackupPath string
+}
+
+// FilterMigration is the DSL rewrite of one section's legacy filter. Skipped
+// lists the qualifiers that could not be translated and were dropped.
+type FilterMigration struct {
+	Location string
+	From     string
+	To       string
+	Skipped  []dsl.ValidationError
+}
+
+// MigrateFilters rewrites the legacy GitHub search filters of every section in
+// the config file at cfgPath into the DSL. The original file is kept next to
+// it with a .bak suffix. With dryRun the file is left untouched.
+func MigrateFilters(cfgPath string, dryRun bool) (MigrateResult, error) {
+	var result MigrateResult
+	file, err := readConfigDocument(cfgPath)
+	if err != nil {
+		return result, err
+	}
+
+	filters := sectionFilterNodes(file.root)
+	for _, filter := range filters {
+		legacy := filter.node.Value
+		if strings.TrimSpace(legacy) == "" {
+			continue
+		}
+		if _, err := dsl.ParseFilterUnexpanded(legacy); err == nil {
+			continue
+		}
+		migration := dsl.MigrateLegacy(legacy)
+		filter.node.Value = migration.Filter
+		if len(migration.Skipped) > 0 {
+			filter.node.LineComment = fmt.Sprintf("# partially migrated from: %s", strings.Join(strings.Fields(legacy), " "))
+		}
+		result.Migrations = append(result.Migrations, FilterMigration{
+			Location: filter.location,
+			From:     legacy,
+			To:       migration.Filter,
+			Skipped:  migration.Skipped,
+		})
+	}
+
+	if dryRun || len(result.Migrations) == 0 {
+		return result, nil
+	}
+	result.BackupPath = cfgPath + ".bak"
+	if err := os.WriteFile(result.BackupPath, file.content, file.mode); err != nil {
+		return result, err
+	}
+	return result, file.write(cfgPath, filters)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateFilters(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	content := `prSections:
+  - title: Mine
+    filters: is:open author:@me sort:updated-asc
+  - title: Org
+    filters: is:open org:dlvhdr
+  - title: DSL
+    filters: state = "open"
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(content), 0o644))
+
+	result, err := MigrateFilters(cfgPath, true)
+	require.NoError(t, err)
+	require.Len(t, result.Migrations, 2)
+	require.Empty(t, result.BackupPath)
+	unchanged, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, content, string(unchanged))
+
+	result, err = MigrateFilters(cfgPath, false)
+	require.NoError(t, err)
+	require.Len(t, result.Migrations, 2)
+	require.Equal(t, `prSections[0] "Mine"`, result.Migrations[0].Location)
+	require.Empty(t, result.Migrations[0].Skipped)
+	require.Len(t, result.Migrations[1].Skipped, 1)
+
+	backup, err := os.ReadFile(result.BackupPath)
+	require.NoError(t, err)
+	require.Equal(t, content, string(backup))
+
+	migrated, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, `prSections:
+  - title: Mine
+    filters: state = "open" and author = "me" order by updated asc
+  - title: Org
+    filters: state = "open" # partially migrated from: is:open org:dlvhdr
+  - title: DSL
+    filters: state = "open"
+`, string(migrated))
+
+	result, err = MigrateFilters(cfgPath, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Migrations)
+}
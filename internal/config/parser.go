@@ -2,11 +2,13 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -19,6 +21,7 @@ import (
 	"github.com/knadh/koanf/v2"
 	yamlmarshaller "gopkg.in/yaml.v3"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
 	"github.com/dlvhdr/gh-dash/v4/internal/utils"
 )
 
@@ -312,6 +315,7 @@ type Config struct {
 	PRSections             []PrsSectionConfig    `yaml:"prSections"`
 	IssuesSections         []IssuesSectionConfig `yaml:"issuesSections"`
 	Repo                   RepoConfig            `yaml:"repo,omitempty"`
+	Filters                map[string]string     `yaml:"filters,omitempty"`
 	Defaults               Defaults              `yaml:"defaults"`
 	Providers              ProvidersConfig       `yaml:"providers,omitempty"`
 	Keybindings            Keybindings           `yaml:"keybindings"`
@@ -726,6 +730,16 @@ func ParseConfig(location Location) (Config, error) {
 	return parser.unmarshalConfigWithDefaults()
 }
 
+// ConfigPath returns the file ParseConfig reads settings from: the provided
+// config if there is one and the global config otherwise.
+func ConfigPath(location Location) (string, error) {
+	parser := initParser()
+	if cfgPath := parser.getProvidedConfigPath(location); cfgPath != "" {
+		return cfgPath, nil
+	}
+	return parser.getGlobalConfigPathOrCreateIfMissing()
+}
+
 func (parser ConfigParser) unmarshalConfigWithDefaults() (Config, error) {
 	cfg := parser.getDefaultConfig()
 	err := parser.k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{Tag: "yaml"})
@@ -739,5 +753,49 @@ func (parser ConfigParser) unmarshalConfigWithDefaults() (Config, error) {
 	}
 
 	err = validate.Struct(cfg)
-	return cfg, err
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, validateFilters(cfg)
+}
+
+// validateFilters checks the filter macros and every section filter
+// against the DSL schema and reports all problems at once. Macros and
+// sections follow the same rules: filters with legacy qualifiers are left to
+// the sections, and without FF_DSL_VALIDATE so is anything that doesn't
+// parse, as it is sent to GitHub as written.
+func validateFilters(cfg Config) error {
+	if err := dsl.CheckMacros(cfg.Filters); err != nil {
+		return err
+	}
+
+	var errs []error
+	dslEnabled := IsFeatureEnabled(FF_DSL_VALIDATE)
+	check := func(label, filters string) {
+		if dsl.IsLegacyFilter(filters) {
+			return
+		}
+		if _, err := dsl.ParseFilterUnexpanded(filters); err != nil && !dslEnabled {
+			return
+		}
+		for _, err := range dsl.CheckFilter(filters, cfg.Filters) {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Filters))
+	for name := range cfg.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check(fmt.Sprintf("filter macro %q", name), cfg.Filters[name])
+	}
+	for _, section := range cfg.PRSections {
+		check(fmt.Sprintf("section %q", section.Title), section.Filters)
+	}
+	for _, section := range cfg.IssuesSections {
+		check(fmt.Sprintf("section %q", section.Title), section.Filters)
+	}
+	return errors.Join(errs...)
 }
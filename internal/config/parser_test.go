@@ -142,6 +142,64 @@ func TestParser(t *testing.T) {
 	})
 }
 
+func TestValidateFilters(t *testing.T) {
+	cfg := Config{
+		Filters: map[string]string{
+			"open":   `state = "open"`,
+			"mine":   `@open and author = "me"`,
+			"recent": `updated > 2026-01-05T09:30:00+01:00`,
+		},
+		PRSections: []PrsSectionConfig{
+			{Title: "Mine", Filters: `@mine and draft = false`},
+			{Title: "Recent", Filters: `@recent and updated < 2026-02-01T00:00:00Z`},
+			{Title: "Legacy", Filters: "is:open author:@me"},
+		},
+	}
+	require.NoError(t, validateFilters(cfg))
+	t.Setenv(FF_DSL_VALIDATE, "1")
+	require.NoError(t, validateFilters(cfg))
+
+	cfg.IssuesSections = []IssuesSectionConfig{{Title: "Broken", Filters: `use("theirs")`}}
+	require.EqualError(t, validateFilters(cfg), `section "Broken": filter macro "theirs": not defined`)
+
+	cfg.Filters["open"] = `state = "open" and @mine`
+	require.EqualError(t, validateFilters(cfg), `filter macro "mine": cycle mine -> open -> mine`)
+}
+
+func TestValidateFiltersReportsTypeErrors(t *testing.T) {
+	cfg := Config{
+		Filters: map[string]string{
+			"stale": `updated < "alice"`,
+		},
+		PRSections: []PrsSectionConfig{
+			{Title: "Drafts", Filters: `draft = "yes" and comments > 3`},
+			{Title: "Legacy", Filters: "is:open author:@me"},
+			{Title: "Search", Filters: "bug"},
+		},
+		IssuesSections: []IssuesSectionConfig{
+			{Title: "Typo", Filters: `asignee = "me" and ci in ["green"]`},
+		},
+	}
+
+	err := validateFilters(cfg)
+	require.Error(t, err)
+	for _, want := range []string{
+		`filter macro "stale": updated expects a date, duration or period, got "alice"`,
+		`section "Drafts": draft expects true or false, got "yes"`,
+		`section "Typo": unknown field "asignee" at 0; did you mean assignee?`,
+		`section "Typo": ci must be one of success, failure, pending, got "green"`,
+	} {
+		require.Contains(t, err.Error(), want)
+	}
+	require.NotContains(t, err.Error(), "Legacy")
+	require.NotContains(t, err.Error(), "Search")
+
+	t.Setenv(FF_DSL_VALIDATE, "1")
+	err = validateFilters(cfg)
+	require.Contains(t, err.Error(), `section "Search": `)
+	require.NotContains(t, err.Error(), "Legacy")
+}
+
 func loadExpected(t *testing.T, fpath string) Config {
 	t.Helper()
 	cwd := Testwd(t)
@@ -0,0 +1,149 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+// ItemRef identifies an issue or pull request by its repository, or GitLab
+// project path, and number.
+type ItemRef struct {
+	Repo   string
+	Number int
+}
+
+const (
+	updatedSinceBatchSize = 50
+	updatedSincePageSize  = 100
+)
+
+// UpdatedSince returns which of refs were updated on provider after since.
+// resource tells GitLab whether refs are merge requests or issues; GitHub
+// looks both up the same way.
+func UpdatedSince(
+	provider providers.Instance,
+	resource GitLabResource,
+	refs []ItemRef,
+	since time.Time,
+) (map[ItemRef]bool, error) {
+	if len(refs) == 0 {
+		return map[ItemRef]bool{}, nil
+	}
+	switch provider.Kind {
+	case providers.KindGitLab:
+		return gitlabUpdatedSince(provider, resource, refs, since)
+	case providers.KindGitHub:
+		return githubUpdatedSince(provider, refs, since)
+	default:
+		return nil, fmt.Errorf("unsupported provider kind %q", provider.Kind)
+	}
+}
+
+type updatedAtNode struct {
+	IssueOrPullRequest *struct {
+		UpdatedAt time.Time `json:"updatedAt"`
+	} `json:"issueOrPullRequest"`
+}
+
+// githubUpdatedSince looks up the refs with one aliased repository field
+// each, batched to keep queries small.
+func githubUpdatedSince(provider providers.Instance, refs []ItemRef, since time.Time) (map[ItemRef]bool, error) {
+	if provider.AuthToken == "" {
+		return nil, fmt.Errorf("missing auth token for host %q", provider.Host)
+	}
+	client, err := gh.NewGraphQLClient(gh.ClientOptions{
+		Host:      provider.Host,
+		AuthToken: provider.AuthToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := map[ItemRef]bool{}
+	for start := 0; start < len(refs); start += updatedSinceBatchSize {
+		batch := refs[start:min(start+updatedSinceBatchSize, len(refs))]
+		var query strings.Builder
+		query.WriteString("query UpdatedSince {")
+		for i, ref := range batch {
+			owner, name, ok := strings.Cut(ref.Repo, "/")
+			if !ok {
+				return nil, fmt.Errorf("invalid repository %q", ref.Repo)
+			}
+			fmt.Fprintf(&query,
+				" r%d: repository(owner: %s, name: %s) { issueOrPullRequest(number: %d) { ... on Issue { updatedAt } ... on PullRequest { updatedAt } } }",
+				i, strconv.Quote(owner), strconv.Quote(name), ref.Number)
+		}
+		query.WriteString(" }")
+
+		response := map[string]*updatedAtNode{}
+		if err := client.Do(query.String(), nil, &response); err != nil {
+			return nil, err
+		}
+		for i, ref := range batch {
+			node := response[fmt.Sprintf("r%d", i)]
+			if node == nil || node.IssueOrPullRequest == nil || node.IssueOrPullRequest.UpdatedAt.After(since) {
+				// an item that can't be found anymore has changed as well
+				changed[ref] = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+type gitlabUpdatedItem struct {
+	IID int `json:"iid"`
+}
+
+// gitlabUpdatedSince lists what changed in each project the refs belong to.
+// Like the translated filters, it skips the second after since because
+// updated_after includes it.
+func gitlabUpdatedSince(
+	provider providers.Instance,
+	resource GitLabResource,
+	refs []ItemRef,
+	since time.Time,
+) (map[ItemRef]bool, error) {
+	projects := map[string]bool{}
+	known := map[ItemRef]bool{}
+	for _, ref := range refs {
+		projects[ref.Repo] = true
+		known[ref] = true
+	}
+
+	changed := map[ItemRef]bool{}
+	for project := range projects {
+		endpoint := fmt.Sprintf("/projects/%s/%s", url.PathEscape(project), resource)
+		for page := 1; ; page++ {
+			body, _, err := gitlabGet(provider, endpoint, map[string]string{
+				"updated_after": since.Add(time.Second).UTC().Format(time.RFC3339),
+				"per_page":      strconv.Itoa(updatedSincePageSize),
+				"page":          strconv.Itoa(page),
+			})
+			if err != nil {
+				return nil, err
+			}
+			var items []gitlabUpdatedItem
+			if err := json.Unmarshal(body, &items); err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				ref := ItemRef{Repo: project, Number: item.IID}
+				if known[ref] {
+					changed[ref] = true
+				}
+			}
+			if len(items) < updatedSincePageSize {
+				break
+			}
+		}
+	}
+	return changed, nil
+}
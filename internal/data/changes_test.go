@@ -0,0 +1,38 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestGitLabUpdatedSince(t *testing.T) {
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/acme%2Fapp/merge_requests" {
+			t.Errorf("unexpected path %q", r.URL.EscapedPath())
+		}
+		if got := r.URL.Query().Get("updated_after"); got != "2026-05-01T12:00:01Z" {
+			t.Errorf("unexpected updated_after %q", got)
+		}
+		w.Write([]byte(`[{"iid":2},{"iid":9}]`))
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:updated-since", Kind: providers.KindGitLab, Host: server.URL}
+
+	changed, err := UpdatedSince(provider, GitLabMergeRequests, []ItemRef{
+		{Repo: "acme/app", Number: 1},
+		{Repo: "acme/app", Number: 2},
+	}, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[ItemRef]bool{{Repo: "acme/app", Number: 2}: true}
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("expected %v, got %v", want, changed)
+	}
+}
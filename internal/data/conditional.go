@@ -0,0 +1,56 @@
+package data
+
+import (
+	"net/http"
+
+	"github.com/maypok86/otter/v2"
+)
+
+// validatedResponse is a response body along with the validators a server
+// sent for it, kept so the next read of the same URL can be conditional.
+type validatedResponse struct {
+	etag         string
+	lastModified string
+	response     gitlabResponse
+}
+
+const validatedResponsesMaxSize = 1_000
+
+var validatedResponses = otter.Must(&otter.Options[string, validatedResponse]{
+	MaximumSize: validatedResponsesMaxSize,
+})
+
+func validatedResponseKey(providerID string, url string) string {
+	return providerID + " " + url
+}
+
+// setConditionalHeaders asks the server to answer 304 Not Modified when the
+// response cached under key is still current.
+func setConditionalHeaders(req *http.Request, key string) {
+	cached, ok := validatedResponses.GetIfPresent(key)
+	if !ok {
+		return
+	}
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
+	}
+}
+
+// storeValidatedResponse keeps a successful response if the server sent
+// validators for it.
+func storeValidatedResponse(key string, header http.Header, res gitlabResponse) {
+	etag := header.Get("ETag")
+	lastModified := header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		validatedResponses.Invalidate(key)
+		return
+	}
+	validatedResponses.Set(key, validatedResponse{
+		etag:         etag,
+		lastModified: lastModified,
+		response:     res,
+	})
+}
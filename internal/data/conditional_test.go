@@ -0,0 +1,38 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestGitLabGetReusesBodyWhenNotModified(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Header().Set("X-Total", "2")
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:test", Kind: providers.KindGitLab, Host: server.URL}
+
+	for i := 0; i < 2; i++ {
+		body, total, err := gitlabGet(provider, "/issues", map[string]string{"state": "opened"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(body) != `[{"id":1},{"id":2}]` || total != 2 {
+			t.Fatalf("request %d: unexpected response %q with total %d", i+1, body, total)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
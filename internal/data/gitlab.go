@@ -7,17 +7,24 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	graphql "github.com/cli/shurcooL-graphql"
+	"golang.org/x/sync/errgroup"
+
 	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
 	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 )
 
+const gitlabPipelineFetchConcurrency = 4
+
 type gitlabMergeRequest struct {
 	IID          int      `json:"iid"`
 	Title        string   `json:"title"`
+	Description  string   `json:"description"`
 	State        string   `json:"state"`
 	WebURL       string   `json:"web_url"`
 	CreatedAt    string   `json:"created_at"`
@@ -34,22 +41,29 @@ type gitlabMergeRequest struct {
 	Assignees []struct {
 		Username string `json:"username"`
 	} `json:"assignees"`
+	Reviewers []struct {
+		Username string `json:"username"`
+	} `json:"reviewers"`
 	Draft          bool   `json:"draft"`
 	WorkInProgress bool   `json:"work_in_progress"`
 	UserNotesCount int    `json:"user_notes_count"`
-	ProjectID      int    `json:"project_id"`
+	Upvotes        int    `json:"upvotes"`
+	Downvotes      int    `json:"downvotes"`
 	MergeStatus    string `json:"merge_status"`
 }
 
 type gitlabIssue struct {
 	IID            int      `json:"iid"`
 	Title          string   `json:"title"`
+	Description    string   `json:"description"`
 	State          string   `json:"state"`
 	WebURL         string   `json:"web_url"`
 	CreatedAt      string   `json:"created_at"`
 	UpdatedAt      string   `json:"updated_at"`
 	Labels         []string `json:"labels"`
 	UserNotesCount int      `json:"user_notes_count"`
+	Upvotes        int      `json:"upvotes"`
+	Downvotes      int      `json:"downvotes"`
 	References     struct {
 		Full string `json:"full"`
 	} `json:"references"`
@@ -61,49 +75,108 @@ type gitlabIssue struct {
 	} `json:"assignees"`
 }
 
-func FetchGitLabMergeRequests(
+type GitLabResource string
+
+const (
+	GitLabMergeRequests GitLabResource = "merge_requests"
+	GitLabIssues        GitLabResource = "issues"
+)
+
+// GitLabRequest is what a GitLab fetch sends for a filter. Skip is set when
+// the filter's provider predicates exclude the instance.
+type GitLabRequest struct {
+	Endpoint string
+	Params   map[string]string
+	Query    dsl.GitLabQuery
+	Skip     bool
+}
+
+// GitLabSortsBy reports whether GitLab lists resource in order. Results in
+// any other order are sorted one page at a time, so later pages may hold
+// items that belong before the ones already shown.
+func GitLabSortsBy(resource GitLabResource, order dsl.OrderBy) bool {
+	switch order.Field {
+	case dsl.SortUpdated, dsl.SortCreated:
+		return true
+	case dsl.SortReactions:
+		return resource == GitLabIssues
+	}
+	return false
+}
+
+// BuildGitLabRequest translates filter into the endpoint and query
+// parameters used to list resource on provider. It may look up the current
+// user, team members and the project ID.
+func BuildGitLabRequest(
 	provider providers.Instance,
 	filter string,
+	resource GitLabResource,
 	limit int,
-) (PullRequestsResponse, error) {
+) (GitLabRequest, error) {
 	expr, err := dsl.ParseFilter(filter)
 	if err != nil {
-		return PullRequestsResponse{}, err
+		return GitLabRequest{}, err
 	}
 	if dsl.RequiresCurrentUser(expr) {
 		username, err := CurrentUser(provider)
 		if err != nil {
-			return PullRequestsResponse{}, err
+			return GitLabRequest{}, err
 		}
 		expr = dsl.ExpandCurrentUser(expr, username)
 	}
-	query, err := dsl.TranslateGitLab(expr, time.Now())
+	expr, err = ExpandTeams(provider, expr, true)
 	if err != nil {
-		return PullRequestsResponse{}, err
+		return GitLabRequest{}, err
+	}
+	query, err := dsl.TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		return GitLabRequest{}, err
 	}
 	if !providerAllowed(provider, query.ProviderFilter) {
-		return PullRequestsResponse{Prs: nil, TotalCount: 0, PageInfo: PageInfo{HasNextPage: false}}, nil
+		return GitLabRequest{Query: query, Skip: true}, nil
 	}
 	params := query.Params
 	params["scope"] = "all"
+	if order := query.Order; order != nil && order.Field == dsl.SortReactions && resource == GitLabIssues {
+		// popularity is the number of upvotes
+		params["order_by"] = "popularity"
+		params["sort"] = string(order.Direction)
+	}
 	if limit > 0 {
 		params["per_page"] = strconv.Itoa(limit)
 	}
-	endpoint := "/merge_requests"
+	endpoint := "/" + string(resource)
 	if query.ProjectPath != "" {
 		projectID, err := gitlabProjectID(provider, query.ProjectPath)
 		if err != nil {
-			return PullRequestsResponse{}, err
+			return GitLabRequest{}, err
 		}
-		endpoint = fmt.Sprintf("/projects/%d/merge_requests", projectID)
+		endpoint = fmt.Sprintf("/projects/%d/%s", projectID, resource)
+	}
+	return GitLabRequest{Endpoint: endpoint, Params: params, Query: query}, nil
+}
+
+func FetchGitLabMergeRequests(
+	provider providers.Instance,
+	filter string,
+	limit int,
+	pageInfo *PageInfo,
+) (PullRequestsResponse, error) {
+	request, err := BuildGitLabRequest(provider, filter, GitLabMergeRequests, limit)
+	if err != nil {
+		return PullRequestsResponse{}, err
+	}
+	if request.Skip {
+		return PullRequestsResponse{Prs: nil, TotalCount: 0, PageInfo: PageInfo{HasNextPage: false}}, nil
 	}
-	body, total, err := gitlabGet(provider, endpoint, params)
+	setGitLabPage(request.Params, pageInfo)
+	res, err := gitlabGetPage(provider, request.Endpoint, request.Params)
 	if err != nil {
 		return PullRequestsResponse{}, err
 	}
 
 	var items []gitlabMergeRequest
-	if err := json.Unmarshal(body, &items); err != nil {
+	if err := json.Unmarshal(res.body, &items); err != nil {
 		return PullRequestsResponse{}, err
 	}
 
@@ -124,6 +197,7 @@ func FetchGitLabMergeRequests(
 		prs = append(prs, PullRequestData{
 			Number:         item.IID,
 			Title:          item.Title,
+			Body:           item.Description,
 			State:          mapGitLabMRState(item.State),
 			Url:            item.WebURL,
 			UpdatedAt:      updatedAt,
@@ -134,8 +208,10 @@ func FetchGitLabMergeRequests(
 			Repository:     Repository{Name: repoName, NameWithOwner: projectPath},
 			HeadRepository: struct{ Name string }{Name: repoName},
 			Comments:       Comments{TotalCount: item.UserNotesCount},
+			Reactions:      IssueReactions{TotalCount: item.Upvotes + item.Downvotes},
 			ReviewThreads:  ReviewThreads{TotalCount: 0},
 			Reviews:        Reviews{TotalCount: 0},
+			ReviewRequests: gitlabReviewRequests(item),
 			Author:         struct{ Login string }{Login: item.Author.Username},
 			Assignees:      Assignees{Nodes: assignees},
 			Labels:         PRLabels{Nodes: labels},
@@ -144,8 +220,8 @@ func FetchGitLabMergeRequests(
 
 	return PullRequestsResponse{
 		Prs:        prs,
-		TotalCount: total,
-		PageInfo:   PageInfo{HasNextPage: false},
+		TotalCount: res.total,
+		PageInfo:   gitlabPageInfo(res),
 	}, nil
 }
 
@@ -192,6 +268,7 @@ func FetchGitLabMergeRequestByBranch(
 	return PullRequestData{
 		Number:         item.IID,
 		Title:          item.Title,
+		Body:           item.Description,
 		State:          mapGitLabMRState(item.State),
 		Url:            item.WebURL,
 		UpdatedAt:      updatedAt,
@@ -204,6 +281,7 @@ func FetchGitLabMergeRequestByBranch(
 		Comments:       Comments{TotalCount: item.UserNotesCount},
 		ReviewThreads:  ReviewThreads{TotalCount: 0},
 		Reviews:        Reviews{TotalCount: 0},
+		ReviewRequests: gitlabReviewRequests(item),
 		Author:         struct{ Login string }{Login: item.Author.Username},
 		Assignees:      Assignees{Nodes: assignees},
 		Labels:         PRLabels{Nodes: labels},
@@ -214,44 +292,22 @@ func FetchGitLabIssues(
 	provider providers.Instance,
 	filter string,
 	limit int,
+	pageInfo *PageInfo,
 ) (IssuesResponse, error) {
-	expr, err := dsl.ParseFilter(filter)
-	if err != nil {
-		return IssuesResponse{}, err
-	}
-	if dsl.RequiresCurrentUser(expr) {
-		username, err := CurrentUser(provider)
-		if err != nil {
-			return IssuesResponse{}, err
-		}
-		expr = dsl.ExpandCurrentUser(expr, username)
-	}
-	query, err := dsl.TranslateGitLab(expr, time.Now())
+	request, err := BuildGitLabRequest(provider, filter, GitLabIssues, limit)
 	if err != nil {
 		return IssuesResponse{}, err
 	}
-	if !providerAllowed(provider, query.ProviderFilter) {
+	if request.Skip {
 		return IssuesResponse{Issues: nil, TotalCount: 0, PageInfo: PageInfo{HasNextPage: false}}, nil
 	}
-	params := query.Params
-	params["scope"] = "all"
-	if limit > 0 {
-		params["per_page"] = strconv.Itoa(limit)
-	}
-	endpoint := "/issues"
-	if query.ProjectPath != "" {
-		projectID, err := gitlabProjectID(provider, query.ProjectPath)
-		if err != nil {
-			return IssuesResponse{}, err
-		}
-		endpoint = fmt.Sprintf("/projects/%d/issues", projectID)
-	}
-	body, total, err := gitlabGet(provider, endpoint, params)
+	setGitLabPage(request.Params, pageInfo)
+	res, err := gitlabGetPage(provider, request.Endpoint, request.Params)
 	if err != nil {
 		return IssuesResponse{}, err
 	}
 	var items []gitlabIssue
-	if err := json.Unmarshal(body, &items); err != nil {
+	if err := json.Unmarshal(res.body, &items); err != nil {
 		return IssuesResponse{}, err
 	}
 	issues := make([]IssueData, 0, len(items))
@@ -271,6 +327,7 @@ func FetchGitLabIssues(
 		issues = append(issues, IssueData{
 			Number:    item.IID,
 			Title:     item.Title,
+			Body:      item.Description,
 			State:     mapGitLabIssueState(item.State),
 			Url:       item.WebURL,
 			UpdatedAt: updatedAt,
@@ -281,45 +338,126 @@ func FetchGitLabIssues(
 			},
 			Assignees: Assignees{Nodes: assignees},
 			Comments:  IssueComments{TotalCount: item.UserNotesCount},
+			Reactions: IssueReactions{TotalCount: item.Upvotes + item.Downvotes},
 			Labels:    IssueLabels{Nodes: labels},
 			Author:    struct{ Login string }{Login: item.Author.Username},
 		})
 	}
 	return IssuesResponse{
 		Issues:     issues,
-		TotalCount: total,
-		PageInfo:   PageInfo{HasNextPage: false},
+		TotalCount: res.total,
+		PageInfo:   gitlabPageInfo(res),
 	}, nil
 }
 
-func gitlabGet(provider providers.Instance, endpoint string, params map[string]string) ([]byte, int, error) {
-	type gitlabResponse struct {
-		body  []byte
-		total int
+// FetchGitLabPipelineStatuses fills in the status check rollup of each merge
+// request from its head pipeline, which the list endpoint doesn't include.
+// That costs a request per merge request, so it's only done for the ones a
+// ci filter has to look at.
+func FetchGitLabPipelineStatuses(provider providers.Instance, prs []*PullRequestData) error {
+	group := errgroup.Group{}
+	group.SetLimit(gitlabPipelineFetchConcurrency)
+	for _, pr := range prs {
+		group.Go(func() error {
+			endpoint := fmt.Sprintf("/projects/%s/merge_requests/%d",
+				url.PathEscape(pr.Repository.NameWithOwner), pr.Number)
+			body, _, err := gitlabGet(provider, endpoint, nil)
+			if err != nil {
+				return err
+			}
+			var mr struct {
+				HeadPipeline *struct {
+					Status string `json:"status"`
+				} `json:"head_pipeline"`
+			}
+			if err := json.Unmarshal(body, &mr); err != nil {
+				return err
+			}
+			if mr.HeadPipeline == nil {
+				return nil
+			}
+			pr.Commits.Nodes = slices.Grow(pr.Commits.Nodes, 1)[:1]
+			pr.Commits.Nodes[0].Commit.StatusCheckRollup.State = graphql.String(
+				mapGitLabPipelineStatus(mr.HeadPipeline.Status))
+			return nil
+		})
 	}
-	res, err := retryRead(func() (gitlabResponse, error) {
-		body, total, err := doGitLabGet(provider, endpoint, params)
-		if err != nil {
-			return gitlabResponse{}, err
-		}
-		return gitlabResponse{body: body, total: total}, nil
-	})
+	return group.Wait()
+}
+
+func mapGitLabPipelineStatus(status string) string {
+	switch status {
+	case "success":
+		return "SUCCESS"
+	case "failed", "canceled":
+		return "FAILURE"
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled":
+		return "PENDING"
+	default:
+		return ""
+	}
+}
+
+// gitlabResponse is the body of a GitLab API response along with the total
+// count and next page from its pagination headers.
+type gitlabResponse struct {
+	body     []byte
+	total    int
+	nextPage string
+}
+
+func gitlabGet(provider providers.Instance, endpoint string, params map[string]string) ([]byte, int, error) {
+	res, err := gitlabGetPage(provider, endpoint, params)
 	if err != nil {
 		return nil, 0, err
 	}
 	return res.body, res.total, nil
 }
 
-func doGitLabGet(provider providers.Instance, endpoint string, params map[string]string) ([]byte, int, error) {
+func gitlabGetPage(provider providers.Instance, endpoint string, params map[string]string) (gitlabResponse, error) {
+	return retryRead(func() (gitlabResponse, error) {
+		return doGitLabGet(provider, endpoint, params)
+	})
+}
+
+// gitlabPageInfo returns the page info of a list response. GitLab pages are
+// numbered, so the cursor is the number of the next page.
+func gitlabPageInfo(res gitlabResponse) PageInfo {
+	return PageInfo{HasNextPage: res.nextPage != "", EndCursor: res.nextPage}
+}
+
+// setGitLabPage asks for the page after pageInfo, if any.
+func setGitLabPage(params map[string]string, pageInfo *PageInfo) {
+	if pageInfo != nil && pageInfo.EndCursor != "" {
+		params["page"] = pageInfo.EndCursor
+	}
+}
+
+// gitlabURL returns the API URL of endpoint on provider. Project and group
+// paths in endpoint are already escaped and have to stay that way.
+func gitlabURL(provider providers.Instance, endpoint string) (*url.URL, error) {
 	baseURL := provider.Host
 	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
 		baseURL = "https://" + baseURL
 	}
 	u, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	rawPath := path.Join(u.EscapedPath(), "/api/v4", endpoint)
+	u.Path, err = url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawPath = rawPath
+	return u, nil
+}
+
+func doGitLabGet(provider providers.Instance, endpoint string, params map[string]string) (gitlabResponse, error) {
+	u, err := gitlabURL(provider, endpoint)
+	if err != nil {
+		return gitlabResponse{}, err
 	}
-	u.Path = path.Join(u.Path, "/api/v4", endpoint)
 	query := u.Query()
 	for key, value := range params {
 		query.Set(key, value)
@@ -328,27 +466,40 @@ func doGitLabGet(provider providers.Instance, endpoint string, params map[string
 
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, 0, err
+		return gitlabResponse{}, err
 	}
 	req.Header.Set("PRIVATE-TOKEN", provider.AuthToken)
+	cacheKey := validatedResponseKey(provider.ID, req.URL.String())
+	setConditionalHeaders(req, cacheKey)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return gitlabResponse{}, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		if cached, ok := validatedResponses.GetIfPresent(cacheKey); ok {
+			return cached.response, nil
+		}
+		return gitlabResponse{}, markRetryable(fmt.Errorf("gitlab request failed: %s without a cached response", resp.Status))
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		err := fmt.Errorf("gitlab request failed: %s", resp.Status)
 		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
-			return nil, 0, markRetryable(err)
+			return gitlabResponse{}, markRetryable(err)
 		}
-		return nil, 0, err
+		return gitlabResponse{}, err
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, 0, err
+		return gitlabResponse{}, err
 	}
-	total := parseTotalCount(resp.Header.Get("X-Total"))
-	return body, total, nil
+	res := gitlabResponse{
+		body:     body,
+		total:    parseTotalCount(resp.Header.Get("X-Total")),
+		nextPage: resp.Header.Get("X-Next-Page"),
+	}
+	storeValidatedResponse(cacheKey, resp.Header, res)
+	return res, nil
 }
 
 func parseTotalCount(totalHeader string) int {
@@ -385,6 +536,18 @@ func gitlabProjectPath(ref string, webURL string) string {
 	return pathPart
 }
 
+// gitlabReviewRequests returns the reviewers of a merge request as its
+// review requests.
+func gitlabReviewRequests(item gitlabMergeRequest) ReviewRequests {
+	requests := ReviewRequests{TotalCount: len(item.Reviewers)}
+	for _, reviewer := range item.Reviewers {
+		var request ReviewRequest
+		request.RequestedReviewer.User.Login = reviewer.Username
+		requests.Nodes = append(requests.Nodes, request)
+	}
+	return requests
+}
+
 func mapGitLabMRState(state string) string {
 	switch strings.ToLower(state) {
 	case "merged":
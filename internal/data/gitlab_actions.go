@@ -6,7 +6,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"path"
 	"strings"
 	"sync"
 
@@ -59,7 +58,9 @@ func GitLabMergeRequestMerge(provider providers.Instance, projectPath string, nu
 	if projectPath == "" {
 		return fmt.Errorf("missing project path")
 	}
-	return gitlabPut(provider, fmt.Sprintf("/projects/%s/merge_requests/%d/merge", url.PathEscape(projectPath), number), nil)
+	// not a gitlabPut, a merge is never retried
+	_, err := gitlabRequest(provider, http.MethodPut, fmt.Sprintf("/projects/%s/merge_requests/%d/merge", url.PathEscape(projectPath), number), nil)
+	return err
 }
 
 func GitLabSetMergeRequestState(provider providers.Instance, projectPath string, number int, state string) error {
@@ -195,21 +196,20 @@ func gitlabPost(provider providers.Instance, endpoint string, values url.Values)
 	return err
 }
 
+// gitlabPut sends an update, which sets fields to the values given and so is
+// safe to retry.
 func gitlabPut(provider providers.Instance, endpoint string, values url.Values) error {
-	_, err := gitlabRequest(provider, http.MethodPut, endpoint, values)
-	return err
+	return RetryIdempotentWrite(func() error {
+		_, err := gitlabRequest(provider, http.MethodPut, endpoint, values)
+		return err
+	})
 }
 
 func gitlabRequest(provider providers.Instance, method string, endpoint string, values url.Values) ([]byte, error) {
-	baseURL := provider.Host
-	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
-		baseURL = "https://" + baseURL
-	}
-	u, err := url.Parse(baseURL)
+	u, err := gitlabURL(provider, endpoint)
 	if err != nil {
 		return nil, err
 	}
-	u.Path = path.Join(u.Path, "/api/v4", endpoint)
 
 	var bodyReader *strings.Reader
 	if values != nil {
@@ -227,11 +227,18 @@ func gitlabRequest(provider providers.Instance, method string, endpoint string,
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &WriteError{Kind: writeErrorKindForTransport(err), Host: provider.Host, Err: err}
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("gitlab request failed: %s", resp.Status)
+		err := fmt.Errorf("gitlab request failed: %s", resp.Status)
+		var body struct {
+			Message any `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&body) == nil && body.Message != nil {
+			err = fmt.Errorf("gitlab request failed: %s: %v", resp.Status, body.Message)
+		}
+		return nil, &WriteError{Kind: writeErrorKindForStatus(resp.StatusCode), Host: provider.Host, Err: err}
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestFetchGitLabIssuesPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total", "3")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`[{"iid":3}]`))
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		w.Write([]byte(`[{"iid":1},{"iid":2}]`))
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:pages", Kind: providers.KindGitLab, Host: server.URL}
+
+	first, err := FetchGitLabIssues(provider, `state = "open"`, 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Issues) != 2 || !first.PageInfo.HasNextPage || first.PageInfo.EndCursor != "2" {
+		t.Fatalf("unexpected first page %+v", first.PageInfo)
+	}
+	second, err := FetchGitLabIssues(provider, `state = "open"`, 2, &first.PageInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Issues) != 1 || second.Issues[0].Number != 3 || second.PageInfo.HasNextPage {
+		t.Fatalf("unexpected second page %+v", second)
+	}
+}
+
+func TestBuildGitLabRequestOrdersIssuesByPopularity(t *testing.T) {
+	provider := providers.Instance{ID: "gitlab:order", Kind: providers.KindGitLab, Host: "gitlab.example.com"}
+	filter := `state = "opened" order by reactions asc`
+
+	issues, err := BuildGitLabRequest(provider, filter, GitLabIssues, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issues.Params["order_by"] != "popularity" || issues.Params["sort"] != "asc" {
+		t.Fatalf("expected issues to be ordered by popularity, got %#v", issues.Params)
+	}
+	mergeRequests, err := BuildGitLabRequest(provider, filter, GitLabMergeRequests, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mergeRequests.Params["order_by"]; ok {
+		t.Fatalf("expected merge requests to be sorted locally, got %#v", mergeRequests.Params)
+	}
+}
+
+func TestFetchGitLabPipelineStatuses(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.EscapedPath())
+		w.Write([]byte(`{"head_pipeline":{"status":"failed"}}`))
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:pipelines", Kind: providers.KindGitLab, Host: server.URL}
+
+	pr := PullRequestData{Number: 7, Repository: Repository{NameWithOwner: "group/app"}}
+	if err := FetchGitLabPipelineStatuses(provider, []*PullRequestData{&pr}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requested) != 1 || requested[0] != "/api/v4/projects/group%2Fapp/merge_requests/7" {
+		t.Fatalf("unexpected requests %v", requested)
+	}
+	if len(pr.Commits.Nodes) != 1 || pr.Commits.Nodes[0].Commit.StatusCheckRollup.State != "FAILURE" {
+		t.Fatalf("expected the pipeline status to be filled in, got %+v", pr.Commits)
+	}
+}
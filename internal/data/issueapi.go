@@ -88,7 +88,10 @@ func (data IssueData) GetCreatedAt() time.Time {
 	return data.CreatedAt
 }
 
-func makeIssuesQuery(query string) string {
+func MakeIssuesQuery(query string) string {
+	if hasSortQualifier(query) {
+		return fmt.Sprintf("is:issue %s", query)
+	}
 	return fmt.Sprintf("is:issue %s sort:updated", query)
 }
 
@@ -116,22 +119,40 @@ func FetchIssues(query string, limit int, pageInfo *PageInfo) (IssuesResponse, e
 	})
 }
 
+type issueSearch struct {
+	Nodes []struct {
+		Issue IssueData `graphql:"... on Issue"`
+	}
+	IssueCount int
+	PageInfo   PageInfo
+}
+
+func (search issueSearch) response() IssuesResponse {
+	issues := make([]IssueData, 0, len(search.Nodes))
+	for _, node := range search.Nodes {
+		if node.Issue.Repository.IsArchived {
+			continue
+		}
+		issues = append(issues, node.Issue)
+	}
+
+	return IssuesResponse{
+		Issues:     issues,
+		TotalCount: search.IssueCount,
+		PageInfo:   search.PageInfo,
+	}
+}
+
 func fetchIssues(client *gh.GraphQLClient, query string, limit int, pageInfo *PageInfo) (IssuesResponse, error) {
 	var queryResult struct {
-		Search struct {
-			Nodes []struct {
-				Issue IssueData `graphql:"... on Issue"`
-			}
-			IssueCount int
-			PageInfo   PageInfo
-		} `graphql:"search(type: ISSUE, first: $limit, after: $endCursor, query: $query)"`
+		Search issueSearch `graphql:"search(type: ISSUE, first: $limit, after: $endCursor, query: $query)"`
 	}
 	var endCursor *string
 	if pageInfo != nil {
 		endCursor = &pageInfo.EndCursor
 	}
 	variables := map[string]any{
-		"query":     graphql.String(makeIssuesQuery(query)),
+		"query":     graphql.String(MakeIssuesQuery(query)),
 		"limit":     graphql.Int(limit),
 		"endCursor": (*graphql.String)(endCursor),
 	}
@@ -141,19 +162,7 @@ func fetchIssues(client *gh.GraphQLClient, query string, limit int, pageInfo *Pa
 	}
 	log.Info("Successfully fetched issues", "query", query, "count", queryResult.Search.IssueCount)
 
-	issues := make([]IssueData, 0, len(queryResult.Search.Nodes))
-	for _, node := range queryResult.Search.Nodes {
-		if node.Issue.Repository.IsArchived {
-			continue
-		}
-		issues = append(issues, node.Issue)
-	}
-
-	return IssuesResponse{
-		Issues:     issues,
-		TotalCount: queryResult.Search.IssueCount,
-		PageInfo:   queryResult.Search.PageInfo,
-	}, nil
+	return queryResult.Search.response(), nil
 }
 
 type IssuesResponse struct {
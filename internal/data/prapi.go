@@ -1,10 +1,12 @@
 package data
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -52,7 +54,8 @@ type PullRequestData struct {
 	}
 	Repository       Repository
 	Assignees        Assignees      `graphql:"assignees(first: 3)"`
-	Comments         Comments       `graphql:"comments"`
+	Comments         Comments       `graphql:"comments(last: 10)"`
+	Reactions        IssueReactions `graphql:"reactions(first: 1) @include(if: $withReactions)"`
 	ReviewThreads    ReviewThreads  `graphql:"reviewThreads"`
 	Reviews          Reviews        `graphql:"reviews(last: 3)"`
 	ReviewRequests   ReviewRequests `graphql:"reviewRequests(last: 5)"`
@@ -168,8 +171,15 @@ type ReviewComments struct {
 	TotalCount int
 }
 
+// Comments are the last comments of a pull request, with only their
+// authors.
 type Comments struct {
 	TotalCount int
+	Nodes      []struct {
+		Author struct {
+			Login string
+		}
+	}
 }
 
 type ReviewThreads struct {
@@ -216,11 +226,39 @@ type ChangedFiles struct {
 
 type ReviewRequests struct {
 	TotalCount int
-	Nodes      []struct {
-		AsCodeOwner bool `graphql:"asCodeOwner"`
+	Nodes      []ReviewRequest
+}
+
+// ReviewRequest is a review requested of a user or, when the user's login is
+// empty, of a team.
+type ReviewRequest struct {
+	AsCodeOwner       bool `graphql:"asCodeOwner"`
+	RequestedReviewer struct {
+		User struct {
+			Login string
+		} `graphql:"... on User"`
+		Team struct {
+			Slug         string
+			Organization struct {
+				Login string
+			}
+		} `graphql:"... on Team"`
 	}
 }
 
+// Reviewer returns the login of the user the review is requested of, or the
+// "org/team-slug" of the team.
+func (request ReviewRequest) Reviewer() string {
+	reviewer := request.RequestedReviewer
+	if reviewer.User.Login != "" {
+		return reviewer.User.Login
+	}
+	if reviewer.Team.Slug == "" {
+		return ""
+	}
+	return reviewer.Team.Organization.Login + "/" + reviewer.Team.Slug
+}
+
 type PRLabel struct {
 	Color string
 	Name  string
@@ -270,10 +308,31 @@ func (data PullRequestData) GetCreatedAt() time.Time {
 	return data.CreatedAt
 }
 
-func makePullRequestsQuery(query string) string {
+func MakePullRequestsQuery(query string) string {
+	if hasSortQualifier(query) {
+		return fmt.Sprintf("is:pr %s", query)
+	}
 	return fmt.Sprintf("is:pr %s sort:updated", query)
 }
 
+// hasSortQualifier reports whether query has a sort: qualifier. Quoted text,
+// such as a title searched for "sort:", doesn't count.
+func hasSortQualifier(query string) bool {
+	quoted := false
+	for i, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case i == 0 || query[i-1] == ' ' || query[i-1] == '\t' || query[i-1] == '(':
+			if strings.HasPrefix(query[i:], "sort:") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type PullRequestsResponse struct {
 	Prs        []PullRequestData
 	TotalCount int
@@ -322,24 +381,45 @@ func FetchPullRequests(query string, limit int, pageInfo *PageInfo) (PullRequest
 	})
 }
 
+type pullRequestSearch struct {
+	Nodes []struct {
+		PullRequest PullRequestData `graphql:"... on PullRequest"`
+	}
+	IssueCount int
+	PageInfo   PageInfo
+}
+
+func (search pullRequestSearch) response() PullRequestsResponse {
+	prs := make([]PullRequestData, 0, len(search.Nodes))
+	for _, node := range search.Nodes {
+		if node.PullRequest.Repository.IsArchived {
+			continue
+		}
+		prs = append(prs, node.PullRequest)
+	}
+
+	return PullRequestsResponse{
+		Prs:        prs,
+		TotalCount: search.IssueCount,
+		PageInfo:   search.PageInfo,
+	}
+}
+
 func fetchPullRequests(client *gh.GraphQLClient, query string, limit int, pageInfo *PageInfo) (PullRequestsResponse, error) {
 	var queryResult struct {
-		Search struct {
-			Nodes []struct {
-				PullRequest PullRequestData `graphql:"... on PullRequest"`
-			}
-			IssueCount int
-			PageInfo   PageInfo
-		} `graphql:"search(type: ISSUE, first: $limit, after: $endCursor, query: $query)"`
+		Search pullRequestSearch `graphql:"search(type: ISSUE, first: $limit, after: $endCursor, query: $query)"`
 	}
 	var endCursor *string
 	if pageInfo != nil {
 		endCursor = &pageInfo.EndCursor
 	}
+	// the results of a raw query are never sorted locally, so they go
+	// without reactions
 	variables := map[string]any{
-		"query":     graphql.String(makePullRequestsQuery(query)),
-		"limit":     graphql.Int(limit),
-		"endCursor": (*graphql.String)(endCursor),
+		"query":         graphql.String(MakePullRequestsQuery(query)),
+		"limit":         graphql.Int(limit),
+		"endCursor":     (*graphql.String)(endCursor),
+		"withReactions": graphql.Boolean(false),
 	}
 	log.Debug("Fetching PRs", "query", query, "limit", limit, "endCursor", endCursor)
 	if err := client.Query("SearchPullRequests", &queryResult, variables); err != nil {
@@ -347,22 +427,16 @@ func fetchPullRequests(client *gh.GraphQLClient, query string, limit int, pageIn
 	}
 	log.Info("Successfully fetched PRs", "count", queryResult.Search.IssueCount)
 
-	prs := make([]PullRequestData, 0, len(queryResult.Search.Nodes))
-	for _, node := range queryResult.Search.Nodes {
-		if node.PullRequest.Repository.IsArchived {
-			continue
-		}
-		prs = append(prs, node.PullRequest)
-	}
-
-	return PullRequestsResponse{
-		Prs:        prs,
-		TotalCount: queryResult.Search.IssueCount,
-		PageInfo:   queryResult.Search.PageInfo,
-	}, nil
+	return queryResult.Search.response(), nil
 }
 
 func FetchPullRequest(prUrl string) (EnrichedPullRequestData, error) {
+	return FetchPullRequestWithContext(context.Background(), prUrl)
+}
+
+// FetchPullRequestWithContext fetches the enriched data of the pull request
+// at prUrl, giving up when ctx is done.
+func FetchPullRequestWithContext(ctx context.Context, prUrl string) (EnrichedPullRequestData, error) {
 	var err error
 	client, err := gh.NewGraphQLClient(gh.ClientOptions{EnableCache: true, CacheTTL: 5 * time.Minute})
 	if err != nil {
@@ -382,7 +456,7 @@ func FetchPullRequest(prUrl string) (EnrichedPullRequestData, error) {
 		"url": githubv4.URI{URL: parsedUrl},
 	}
 	log.Debug("Fetching PR", "url", prUrl)
-	err = client.Query("FetchPullRequest", &queryResult, variables)
+	err = client.QueryWithContext(ctx, "FetchPullRequest", &queryResult, variables)
 	if err != nil {
 		return EnrichedPullRequestData{}, err
 	}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHost      = "github.com"
+	reachableTimeout = 3 * time.Second
+)
+
+// IsReachable reports whether host answers an HTTP request, telling a
+// request that failed because the network is down from one the host
+// rejected. The request goes through the proxy from the environment like
+// the API requests do, so any response counts, whatever its status.
+func IsReachable(host string) bool {
+	if host == "" {
+		host = defaultHost
+	}
+	url := host
+	if !strings.Contains(url, "://") {
+		url = "https://" + url
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), reachableTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
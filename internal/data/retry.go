@@ -53,6 +53,10 @@ func shouldRetry(err error) bool {
 	if errors.As(err, &retryable) {
 		return true
 	}
+	var writeErr *WriteError
+	if errors.As(err, &writeErr) {
+		return writeErr.Retryable()
+	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		return true
 	}
@@ -0,0 +1,211 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+const (
+	// searchBatchWindow is how long a search waits for others to share its
+	// request with. Sections are fetched all at once, so this is short.
+	searchBatchWindow = 20 * time.Millisecond
+	// GitHub limits the nodes a query may request. A pull request or issue
+	// requests well under 100 nested nodes, so capping the items of a batch
+	// keeps it far from the limit of 500,000.
+	searchBatchMaxSearches = 10
+	searchBatchMaxItems    = 500
+)
+
+type searchRequest struct {
+	query  string
+	limit  int
+	after  *string
+	result reflect.Type
+	// reactions is whether a pull request search fetches reactions. It is
+	// one variable of the query, so it splits batches.
+	reactions bool
+	done      chan searchResult
+}
+
+type searchResult struct {
+	value any
+	err   error
+}
+
+type searchBatch struct {
+	options  gh.ClientOptions
+	requests []*searchRequest
+}
+
+var searchBatches = struct {
+	mu      sync.Mutex
+	pending map[string]*searchBatch
+}{
+	pending: map[string]*searchBatch{},
+}
+
+// SearchPullRequests fetches a page of the pull requests matching query from
+// the GitHub host in options, with their reactions when withReactions is
+// set. Searches of the same host that start at about the same time share one
+// request.
+func SearchPullRequests(
+	options gh.ClientOptions,
+	query string,
+	withReactions bool,
+	limit int,
+	pageInfo *PageInfo,
+) (PullRequestsResponse, error) {
+	log.Debug("Fetching PRs", "query", query, "limit", limit, "host", options.Host)
+	value, err := batchSearch(options, MakePullRequestsQuery(query), limit, pageInfo, pullRequestSearch{}, withReactions)
+	if err != nil {
+		return PullRequestsResponse{}, err
+	}
+	return value.(*pullRequestSearch).response(), nil
+}
+
+// SearchIssues fetches a page of the issues matching query like
+// SearchPullRequests.
+func SearchIssues(options gh.ClientOptions, query string, limit int, pageInfo *PageInfo) (IssuesResponse, error) {
+	log.Debug("Fetching issues", "query", query, "limit", limit, "host", options.Host)
+	value, err := batchSearch(options, MakeIssuesQuery(query), limit, pageInfo, issueSearch{}, false)
+	if err != nil {
+		return IssuesResponse{}, err
+	}
+	return value.(*issueSearch).response(), nil
+}
+
+// batchSearch queues a search and waits for the batch it joined to be sent.
+// result is the connection type the search is decoded into.
+func batchSearch(options gh.ClientOptions, query string, limit int, pageInfo *PageInfo, result any, reactions bool) (any, error) {
+	request := &searchRequest{
+		query:     query,
+		limit:     limit,
+		result:    reflect.TypeOf(result),
+		reactions: reactions,
+		done:      make(chan searchResult, 1),
+	}
+	if pageInfo != nil {
+		after := pageInfo.EndCursor
+		request.after = &after
+	}
+
+	key := options.Host + "\x00" + options.AuthToken
+	if reactions {
+		key += "\x00reactions"
+	}
+	searchBatches.mu.Lock()
+	batch, ok := searchBatches.pending[key]
+	if !ok {
+		batch = &searchBatch{options: options}
+		searchBatches.pending[key] = batch
+		time.AfterFunc(searchBatchWindow, func() {
+			flushSearchBatch(key)
+		})
+	}
+	batch.requests = append(batch.requests, request)
+	searchBatches.mu.Unlock()
+
+	res := <-request.done
+	return res.value, res.err
+}
+
+func flushSearchBatch(key string) {
+	searchBatches.mu.Lock()
+	batch := searchBatches.pending[key]
+	delete(searchBatches.pending, key)
+	searchBatches.mu.Unlock()
+
+	client, err := gh.NewGraphQLClient(batch.options)
+	if err != nil {
+		for _, request := range batch.requests {
+			request.done <- searchResult{err: err}
+		}
+		return
+	}
+	for _, chunk := range splitSearches(batch.requests) {
+		go runSearches(client, chunk)
+	}
+}
+
+// splitSearches groups requests into batches within the limits, in the
+// order they were queued.
+func splitSearches(requests []*searchRequest) [][]*searchRequest {
+	var chunks [][]*searchRequest
+	var chunk []*searchRequest
+	items := 0
+	for _, request := range requests {
+		if len(chunk) > 0 && (len(chunk) == searchBatchMaxSearches || items+request.limit > searchBatchMaxItems) {
+			chunks = append(chunks, chunk)
+			chunk = nil
+			items = 0
+		}
+		chunk = append(chunk, request)
+		items += request.limit
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func runSearches(client *gh.GraphQLClient, requests []*searchRequest) {
+	values, err := retryRead(func() ([]any, error) {
+		return querySearches(client, requests)
+	})
+	if err != nil && len(requests) > 1 {
+		// a single bad search fails the whole query, so send them one by one
+		// to only fail that one
+		log.Debug("Batched search failed, retrying searches separately", "count", len(requests), "err", err)
+		for _, request := range requests {
+			go runSearches(client, []*searchRequest{request})
+		}
+		return
+	}
+	for i, request := range requests {
+		if err != nil {
+			request.done <- searchResult{err: err}
+			continue
+		}
+		request.done <- searchResult{value: values[i]}
+	}
+}
+
+// querySearches sends requests as one query, with an aliased search field
+// for each, and returns a pointer to each search's decoded connection.
+func querySearches(client *gh.GraphQLClient, requests []*searchRequest) ([]any, error) {
+	fields := make([]reflect.StructField, 0, len(requests))
+	variables := make(map[string]any, 3*len(requests)+1)
+	for i, request := range requests {
+		if request.result == reflect.TypeOf(pullRequestSearch{}) {
+			variables["withReactions"] = graphql.Boolean(request.reactions)
+		}
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("S%d", i),
+			Type: request.result,
+			Tag: reflect.StructTag(fmt.Sprintf(
+				`graphql:"s%d: search(type: ISSUE, first: $limit%d, after: $endCursor%d, query: $query%d)"`,
+				i, i, i, i)),
+		})
+		variables[fmt.Sprintf("query%d", i)] = graphql.String(request.query)
+		variables[fmt.Sprintf("limit%d", i)] = graphql.Int(request.limit)
+		variables[fmt.Sprintf("endCursor%d", i)] = (*graphql.String)(request.after)
+	}
+
+	result := reflect.New(reflect.StructOf(fields))
+	if err := client.Query("BatchedSearch", result.Interface(), variables); err != nil {
+		return nil, err
+	}
+	log.Info("Successfully fetched batched searches", "count", len(requests))
+
+	values := make([]any, 0, len(requests))
+	for i := range requests {
+		values = append(values, result.Elem().Field(i).Addr().Interface())
+	}
+	return values, nil
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestSearchesShareOneRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unexpected body: %v", err)
+		}
+		if !strings.Contains(body.Query, "s0: search(") || !strings.Contains(body.Query, "s1: search(") {
+			t.Errorf("expected aliased searches, got %q", body.Query)
+		}
+		if body.Variables["withReactions"] != false {
+			t.Errorf("expected pull requests without reactions, got %v", body.Variables["withReactions"])
+		}
+		counts := map[string]int{}
+		for _, alias := range []string{"0", "1"} {
+			if strings.HasPrefix(body.Variables["query"+alias].(string), "is:pr ") {
+				counts["s"+alias] = 3
+			} else {
+				counts["s"+alias] = 7
+			}
+		}
+		w.Write([]byte(`{"data":{` +
+			`"s0":{"issueCount":` + jsonInt(counts["s0"]) + `,"pageInfo":{"hasNextPage":false},"nodes":[]},` +
+			`"s1":{"issueCount":` + jsonInt(counts["s1"]) + `,"pageInfo":{"hasNextPage":false},"nodes":[]}}}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	options := gh.ClientOptions{
+		Host:      serverURL.Host,
+		AuthToken: "token",
+		Transport: server.Client().Transport,
+	}
+
+	var wg sync.WaitGroup
+	var prs PullRequestsResponse
+	var issues IssuesResponse
+	var prsErr, issuesErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		prs, prsErr = SearchPullRequests(options, "repo:acme/app", false, 10, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		issues, issuesErr = SearchIssues(options, "repo:acme/app", 10, nil)
+	}()
+	wg.Wait()
+
+	if prsErr != nil || issuesErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", prsErr, issuesErr)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+	if prs.TotalCount != 3 || issues.TotalCount != 7 {
+		t.Fatalf("expected counts 3 and 7, got %d and %d", prs.TotalCount, issues.TotalCount)
+	}
+}
+
+func TestSearchPullRequestsWithReactions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unexpected body: %v", err)
+		}
+		if body.Variables["withReactions"] != true {
+			t.Errorf("expected pull requests with reactions, got %v", body.Variables["withReactions"])
+		}
+		w.Write([]byte(`{"data":{"s0":{"issueCount":0,"pageInfo":{"hasNextPage":false},"nodes":[]}}}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	options := gh.ClientOptions{
+		Host:      serverURL.Host,
+		AuthToken: "token",
+		Transport: server.Client().Transport,
+	}
+
+	if _, err := SearchPullRequests(options, "repo:acme/app sort:reactions-desc", true, 10, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMakePullRequestsQueryKeepsDefaultSort(t *testing.T) {
+	for query, expected := range map[string]string{
+		`repo:acme/app sort:created-asc`: `is:pr repo:acme/app sort:created-asc`,
+		`(sort:reactions-desc)`:          `is:pr (sort:reactions-desc)`,
+		`repo:acme/app "sort:"`:          `is:pr repo:acme/app "sort:" sort:updated`,
+		`"fix sort:order" in:title`:      `is:pr "fix sort:order" in:title sort:updated`,
+	} {
+		if actual := MakePullRequestsQuery(query); actual != expected {
+			t.Fatalf("expected %q for %q, got %q", expected, query, actual)
+		}
+	}
+}
+
+func TestSplitSearches(t *testing.T) {
+	var requests []*searchRequest
+	for range searchBatchMaxSearches + 1 {
+		requests = append(requests, &searchRequest{limit: 20})
+	}
+	requests = append(requests, &searchRequest{limit: searchBatchMaxItems})
+
+	chunks := splitSearches(requests)
+	var sizes []int
+	for _, chunk := range chunks {
+		sizes = append(sizes, len(chunk))
+	}
+	if len(sizes) != 3 || sizes[0] != searchBatchMaxSearches || sizes[1] != 1 || sizes[2] != 1 {
+		t.Fatalf("unexpected chunk sizes %v", sizes)
+	}
+}
+
+func jsonInt(n int) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
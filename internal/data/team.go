@@ -0,0 +1,156 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
+	graphql "github.com/cli/shurcooL-graphql"
+	"github.com/maypok86/otter/v2"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+const (
+	teamMembersCacheTTL     = 10 * time.Minute
+	teamMembersCacheMaxSize = 1_000
+	teamMembersPageSize     = 100
+)
+
+var teamMembersCache = otter.Must(&otter.Options[string, []string]{
+	MaximumSize:      teamMembersCacheMaxSize,
+	ExpiryCalculator: otter.ExpiryWriting[string, []string](teamMembersCacheTTL),
+})
+
+// TeamMembers returns the logins of the members of team on provider. On
+// GitHub team is "org/team-slug" and its members include those of its child
+// teams. On GitLab it is the full path of a group and its members include
+// those inherited from parent groups, which GitHub teams have no notion of.
+func TeamMembers(provider providers.Instance, team string) ([]string, error) {
+	cacheKey := provider.ID + ":" + strings.ToLower(team)
+	if cached, ok := teamMembersCache.GetIfPresent(cacheKey); ok {
+		return cached, nil
+	}
+
+	var members []string
+	var err error
+	switch provider.Kind {
+	case providers.KindGitLab:
+		members, err = gitlabGroupMembers(provider, team)
+	case providers.KindGitHub:
+		members, err = githubTeamMembers(provider, team)
+	default:
+		err = fmt.Errorf("unsupported provider kind %q", provider.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve team %q for %s: %w", team, provider.ID, err)
+	}
+
+	teamMembersCache.Set(cacheKey, members)
+	return members, nil
+}
+
+// ExpandTeams resolves the teams in expr on provider. GitHub can search for
+// review requests to a team, so those are kept unless expandAll is set.
+func ExpandTeams(provider providers.Instance, expr dsl.Expr, expandAll bool) (dsl.Expr, error) {
+	if !dsl.RequiresTeams(expr) {
+		return expr, nil
+	}
+	var keep []string
+	if provider.Kind == providers.KindGitHub && !expandAll {
+		keep = []string{"review_requested"}
+	}
+	return dsl.ExpandTeams(expr, func(team string) ([]string, error) {
+		return TeamMembers(provider, team)
+	}, keep...)
+}
+
+type teamMembersQuery struct {
+	Organization struct {
+		Team *struct {
+			Members struct {
+				Nodes []struct {
+					Login string
+				}
+				PageInfo PageInfo
+			} `graphql:"members(first: $limit, after: $endCursor)"`
+		} `graphql:"team(slug: $slug)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+func githubTeamMembers(provider providers.Instance, team string) ([]string, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return nil, fmt.Errorf("expected a team such as \"org/team-slug\"")
+	}
+	if provider.AuthToken == "" {
+		return nil, fmt.Errorf("missing auth token for host %q", provider.Host)
+	}
+	client, err := gh.NewGraphQLClient(gh.ClientOptions{
+		Host:      provider.Host,
+		AuthToken: provider.AuthToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []string
+	var cursor *string
+	for {
+		var query teamMembersQuery
+		variables := map[string]any{
+			"org":       graphql.String(org),
+			"slug":      graphql.String(slug),
+			"limit":     graphql.Int(teamMembersPageSize),
+			"endCursor": (*graphql.String)(cursor),
+		}
+		if err := client.Query("TeamMembers", &query, variables); err != nil {
+			return nil, err
+		}
+		if query.Organization.Team == nil {
+			return nil, fmt.Errorf("team not found")
+		}
+		page := query.Organization.Team.Members
+		for _, node := range page.Nodes {
+			members = append(members, node.Login)
+		}
+		if !page.PageInfo.HasNextPage {
+			return members, nil
+		}
+		next := page.PageInfo.EndCursor
+		cursor = &next
+	}
+}
+
+type gitlabGroupMember struct {
+	Username string `json:"username"`
+}
+
+func gitlabGroupMembers(provider providers.Instance, group string) ([]string, error) {
+	endpoint := fmt.Sprintf("/groups/%s/members/all", url.PathEscape(group))
+	var members []string
+	for page := 1; ; page++ {
+		body, _, err := gitlabGet(provider, endpoint, map[string]string{
+			"per_page": strconv.Itoa(teamMembersPageSize),
+			"page":     strconv.Itoa(page),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var items []gitlabGroupMember
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			members = append(members, item.Username)
+		}
+		if len(items) < teamMembersPageSize {
+			return members, nil
+		}
+	}
+}
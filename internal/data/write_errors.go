@@ -0,0 +1,210 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type WriteErrorKind int
+
+const (
+	WriteErrorUnknown WriteErrorKind = iota
+	WriteErrorAuth
+	WriteErrorPermission
+	// WriteErrorConflict is a write the item's current state doesn't
+	// allow, like merging a pull request that has conflicts.
+	WriteErrorConflict
+	WriteErrorNotFound
+	WriteErrorRateLimited
+	// WriteErrorUnavailable is a write the host couldn't take, because it
+	// failed.
+	WriteErrorUnavailable
+	// WriteErrorUnreachable is a write that never left the client, because
+	// the host's name didn't resolve or no connection could be opened.
+	WriteErrorUnreachable
+	// WriteErrorNetwork is a write whose connection failed after it was
+	// sent, so it may have been applied anyway.
+	WriteErrorNetwork
+)
+
+// WriteError is a write that failed, classified so the user can be told
+// what to do about it. Err is the error as the host or CLI reported it.
+type WriteError struct {
+	Kind WriteErrorKind
+	Host string
+	Err  error
+}
+
+// Error tells what to do about the error, followed by what the host said.
+func (e *WriteError) Error() string {
+	host := e.Host
+	if host == "" {
+		host = defaultHost
+	}
+	var hint string
+	switch e.Kind {
+	case WriteErrorAuth:
+		hint = fmt.Sprintf("not authenticated with %s, log in again or refresh the token", host)
+	case WriteErrorPermission:
+		hint = fmt.Sprintf("missing permission for this on %s", host)
+	case WriteErrorConflict:
+		hint = "the item doesn't allow this anymore, refresh it and try again"
+	case WriteErrorNotFound:
+		hint = fmt.Sprintf("not found on %s, it may have been moved or deleted", host)
+	case WriteErrorRateLimited:
+		hint = fmt.Sprintf("rate limited by %s, try again in a few minutes", host)
+	case WriteErrorUnavailable:
+		hint = fmt.Sprintf("%s is unavailable, try again later", host)
+	case WriteErrorUnreachable:
+		hint = fmt.Sprintf("couldn't connect to %s, check the network", host)
+	case WriteErrorNetwork:
+		hint = fmt.Sprintf("the connection to %s failed, check whether this went through", host)
+	default:
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", hint, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether sending the write again may succeed.
+func (e *WriteError) Retryable() bool {
+	switch e.Kind {
+	case WriteErrorRateLimited, WriteErrorUnavailable, WriteErrorUnreachable, WriteErrorNetwork:
+		return true
+	}
+	return false
+}
+
+// Unsent reports whether the write never reached the host, so sending it
+// again can't apply it twice.
+func (e *WriteError) Unsent() bool {
+	return e.Kind == WriteErrorUnreachable
+}
+
+// IsRetryableWriteError reports whether err is a write error that may go
+// away on its own.
+func IsRetryableWriteError(err error) bool {
+	var writeErr *WriteError
+	return errors.As(err, &writeErr) && writeErr.Retryable()
+}
+
+// IsUnsentWriteError reports whether err is a write that never reached its
+// host.
+func IsUnsentWriteError(err error) bool {
+	var writeErr *WriteError
+	return errors.As(err, &writeErr) && writeErr.Unsent()
+}
+
+// IsNetworkWriteError reports whether err is a write that failed on the
+// network rather than being answered by its host.
+func IsNetworkWriteError(err error) bool {
+	var writeErr *WriteError
+	return errors.As(err, &writeErr) &&
+		(writeErr.Kind == WriteErrorUnreachable || writeErr.Kind == WriteErrorNetwork)
+}
+
+// writeErrorKindForTransport classifies a request that got no response.
+// Resolving the host or opening the connection, directly or through the
+// proxy, fails before anything is sent.
+func writeErrorKindForTransport(err error) WriteErrorKind {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return WriteErrorUnreachable
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect") {
+		return WriteErrorUnreachable
+	}
+	return WriteErrorNetwork
+}
+
+func writeErrorKindForStatus(status int) WriteErrorKind {
+	switch {
+	case status == http.StatusUnauthorized:
+		return WriteErrorAuth
+	case status == http.StatusForbidden:
+		return WriteErrorPermission
+	case status == http.StatusNotFound:
+		return WriteErrorNotFound
+	case status == http.StatusTooManyRequests:
+		return WriteErrorRateLimited
+	case status == http.StatusMethodNotAllowed, status == http.StatusNotAcceptable,
+		status == http.StatusConflict, status == http.StatusPreconditionFailed:
+		// GitLab answers 405 and 406 to merges and approvals the merge
+		// request's state doesn't allow. 422 is left unknown, it's mostly
+		// an invalid value the host's message explains best.
+		return WriteErrorConflict
+	case status >= 500:
+		return WriteErrorUnavailable
+	}
+	return WriteErrorUnknown
+}
+
+// writeErrorKindForOutput classifies a failed gh command from what it
+// printed, since its exit code doesn't tell. Only HTTP statuses and whole
+// messages of the API are matched: gh prints validation failures like
+// "'nope' not found" in its own words, and those are better left unknown
+// than misread.
+func writeErrorKindForOutput(output string) WriteErrorKind {
+	output = strings.ToLower(output)
+	contains := func(substrings ...string) bool {
+		for _, s := range substrings {
+			if strings.Contains(output, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	// GitHub answers 403 when rate limited, so look for that first
+	case contains("http 429", "api rate limit exceeded", "secondary rate limit"):
+		return WriteErrorRateLimited
+	case contains("http 401", "bad credentials"):
+		return WriteErrorAuth
+	case contains("http 403", "resource not accessible by"):
+		return WriteErrorPermission
+	case contains("http 404", "could not resolve to"):
+		return WriteErrorNotFound
+	case contains("http 409", "is not mergeable"):
+		return WriteErrorConflict
+	case contains("http 500", "http 502", "http 503", "http 504"):
+		return WriteErrorUnavailable
+	// gh reports a host that doesn't resolve as "error connecting to"
+	case contains("error connecting to", "no such host", "dial tcp", "proxyconnect"):
+		return WriteErrorUnreachable
+	case contains("connection reset", "i/o timeout", "unexpected eof", "tls handshake timeout"):
+		return WriteErrorNetwork
+	}
+	return WriteErrorUnknown
+}
+
+// CLIWriteError classifies err, the failure of a gh command writing to host,
+// by the output it printed.
+func CLIWriteError(host string, output string, err error) error {
+	if err == nil {
+		return nil
+	}
+	output = strings.TrimSpace(output)
+	if output != "" {
+		lines := strings.Split(output, "\n")
+		err = fmt.Errorf("%s: %w", strings.TrimSpace(lines[len(lines)-1]), err)
+	}
+	return &WriteError{Kind: writeErrorKindForOutput(output), Host: host, Err: err}
+}
+
+// RetryIdempotentWrite runs a write that has the same effect however often
+// it's sent, like setting the labels of an item, and retries it like a read
+// when it fails on a transient error. Other writes must not be retried: one
+// that timed out may have been applied anyway.
+func RetryIdempotentWrite(fn func() error) error {
+	_, err := retryRead(func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestGitLabWritesRetryOnlyIdempotentCalls(t *testing.T) {
+	var puts, posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			if puts.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{}`))
+		case http.MethodPost:
+			posts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:writes", Kind: providers.KindGitLab, Host: server.URL}
+
+	if err := GitLabSetIssueLabels(provider, "group/project", 1, []string{"bug"}); err != nil {
+		t.Fatalf("expected labels to be set after retrying, got %v", err)
+	}
+	if puts.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", puts.Load())
+	}
+
+	err := GitLabIssueComment(provider, "group/project", 1, "hi")
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) || writeErr.Kind != WriteErrorUnavailable {
+		t.Fatalf("expected an unavailable write error, got %v", err)
+	}
+	if posts.Load() != 1 {
+		t.Fatalf("expected a comment to be sent once, got %d", posts.Load())
+	}
+}
+
+func TestGitLabWriteErrorKinds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"403 Forbidden"}`))
+	}))
+	defer server.Close()
+	provider := providers.Instance{ID: "gitlab:forbidden", Kind: providers.KindGitLab, Host: server.URL}
+
+	err := GitLabSetIssueState(provider, "group/project", 1, "close")
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) || writeErr.Kind != WriteErrorPermission {
+		t.Fatalf("expected a permission error, got %v", err)
+	}
+	if writeErr.Err.Error() != "gitlab request failed: 403 Forbidden: 403 Forbidden" {
+		t.Fatalf("expected the reported error to be kept, got %q", writeErr.Err)
+	}
+}
+
+func TestCLIWriteErrorKinds(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	cases := map[string]WriteErrorKind{
+		"HTTP 401: Bad credentials (https://api.github.com/graphql)":                              WriteErrorAuth,
+		"GraphQL: Resource not accessible by integration (addLabelsToLabelable)":                  WriteErrorPermission,
+		"HTTP 403: API rate limit exceeded for user ID 1.":                                        WriteErrorRateLimited,
+		"GraphQL: Could not resolve to a PullRequest with the number of 9. (repository)":          WriteErrorNotFound,
+		"Pull request owner/repo#1 is not mergeable: the merge commit cannot be cleanly created.": WriteErrorConflict,
+		"HTTP 502: Bad Gateway (https://api.github.com/graphql)":                                  WriteErrorUnavailable,
+		"error connecting to api.github.com":                                                      WriteErrorUnreachable,
+		"Post \"https://api.github.com/graphql\": read tcp: connection reset by peer":             WriteErrorNetwork,
+		"'nope' not found": WriteErrorUnknown,
+		"HTTP 422: Validation Failed (https://api.github.com/repos/o/r/issues/1/labels)": WriteErrorUnknown,
+		"something else": WriteErrorUnknown,
+	}
+	for output, want := range cases {
+		err := CLIWriteError("github.com", output, exitErr)
+		var writeErr *WriteError
+		if !errors.As(err, &writeErr) || writeErr.Kind != want {
+			t.Fatalf("expected %q to be kind %d, got %v", output, want, err)
+		}
+		if !errors.Is(err, exitErr) {
+			t.Fatalf("expected %v to wrap the command's error", err)
+		}
+	}
+	err := CLIWriteError("github.com", "HTTP 404: Not Found (https://api.github.com/repos/o/r)", exitErr)
+	want := "not found on github.com, it may have been moved or deleted: HTTP 404: Not Found (https://api.github.com/repos/o/r): exit status 1"
+	if err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err)
+	}
+	if CLIWriteError("github.com", "output", nil) != nil {
+		t.Fatalf("expected no error for a command that succeeded")
+	}
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"slices"
+
+	checks "github.com/dlvhdr/x/gh-checks"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func (pr PullRequest) FilterField(field string) (any, bool) {
+	if pr.Primary == nil {
+		return nil, false
+	}
+	switch field {
+	case "project":
+		return pr.Primary.Repository.NameWithOwner, true
+	case "state":
+		return pr.Primary.State, true
+	case "type":
+		return string(WorkItemPullRequest), true
+	case "author":
+		return pr.Primary.Author.Login, true
+	case "assignee":
+		return assigneeLogins(pr.Primary.Assignees), true
+	case "review_requested":
+		return reviewerLogins(pr.Primary.ReviewRequests), true
+	case "involves":
+		return involvedLogins(pr.Primary.Author.Login, pr.Primary.Assignees, pullRequestCommenters(pr.Primary)), true
+	case "label":
+		return labelNames(pr.Primary.Labels.Nodes), true
+	case "head":
+		return pr.Primary.HeadRefName, true
+	case "base":
+		return pr.Primary.BaseRefName, true
+	case "ci":
+		return ciStatus(pr.Primary.Commits), true
+	case "draft":
+		return pr.Primary.IsDraft, true
+	case "archived":
+		return pr.Primary.Repository.IsArchived, true
+	case "updated":
+		return pr.Primary.UpdatedAt, true
+	case "created":
+		return pr.Primary.CreatedAt, true
+	case "comments":
+		return pr.Primary.Comments.TotalCount, true
+	case "reactions":
+		return pr.Primary.Reactions.TotalCount, true
+	case "text":
+		return pr.Primary.Title + "\n" + pr.Primary.Body, true
+	case "title":
+		return pr.Primary.Title, true
+	case "body":
+		return pr.Primary.Body, true
+	default:
+		return nil, false
+	}
+}
+
+func (issue Issue) FilterField(field string) (any, bool) {
+	// an issue without data has no fields, like a pull request without
+	// primary data. Every issue has a number.
+	if issue.Data.Number == 0 {
+		return nil, false
+	}
+	switch field {
+	case "project":
+		return issue.Data.Repository.NameWithOwner, true
+	case "state":
+		return issue.Data.State, true
+	case "type":
+		return string(WorkItemIssue), true
+	case "author":
+		return issue.Data.Author.Login, true
+	case "assignee":
+		return assigneeLogins(issue.Data.Assignees), true
+	case "review_requested":
+		// reviews are only requested of pull requests
+		return []string{}, true
+	case "involves":
+		return involvedLogins(issue.Data.Author.Login, issue.Data.Assignees, issueCommenters(issue.Data)), true
+	case "label":
+		return labelNames(issue.Data.Labels.Nodes), true
+	case "archived":
+		return issue.Data.Repository.IsArchived, true
+	case "updated":
+		return issue.Data.UpdatedAt, true
+	case "created":
+		return issue.Data.CreatedAt, true
+	case "comments":
+		return issue.Data.Comments.TotalCount, true
+	case "reactions":
+		return issue.Data.Reactions.TotalCount, true
+	case "text":
+		return issue.Data.Title + "\n" + issue.Data.Body, true
+	case "title":
+		return issue.Data.Title, true
+	case "body":
+		return issue.Data.Body, true
+	default:
+		return nil, false
+	}
+}
+
+// ciStatus maps the status check rollup of the last commit to the values
+// of the ci field. A PR without checks has no status and matches none of them.
+func ciStatus(commits data.Commits) string {
+	if len(commits.Nodes) == 0 {
+		return ""
+	}
+	switch checks.CommitState(commits.Nodes[0].Commit.StatusCheckRollup.State) {
+	case checks.CommitStateSuccess:
+		return "success"
+	case checks.CommitStateFailure, checks.CommitStateError:
+		return "failure"
+	case checks.CommitStatePending, checks.CommitStateExpected:
+		return "pending"
+	default:
+		return ""
+	}
+}
+
+func assigneeLogins(assignees data.Assignees) []string {
+	logins := make([]string, 0, len(assignees.Nodes))
+	for _, assignee := range assignees.Nodes {
+		logins = append(logins, assignee.Login)
+	}
+	return logins
+}
+
+func reviewerLogins(requests data.ReviewRequests) []string {
+	logins := make([]string, 0, len(requests.Nodes))
+	for _, request := range requests.Nodes {
+		if reviewer := request.Reviewer(); reviewer != "" {
+			logins = append(logins, reviewer)
+		}
+	}
+	return logins
+}
+
+// involvedLogins returns the users involved in an item as far as the fetched
+// data tells: its author, assignees and the authors of its last comments.
+// Unlike GitHub's involves qualifier, users only mentioned aren't included.
+func involvedLogins(author string, assignees data.Assignees, commenters []string) []string {
+	logins := assigneeLogins(assignees)
+	if author != "" && !slices.Contains(logins, author) {
+		logins = append(logins, author)
+	}
+	for _, commenter := range commenters {
+		if !slices.Contains(logins, commenter) {
+			logins = append(logins, commenter)
+		}
+	}
+	return logins
+}
+
+// pullRequestCommenters returns the authors of the last comments and reviews
+// of pr.
+func pullRequestCommenters(pr *data.PullRequestData) []string {
+	commenters := make([]string, 0, len(pr.Comments.Nodes)+len(pr.Reviews.Nodes))
+	for _, comment := range pr.Comments.Nodes {
+		commenters = append(commenters, comment.Author.Login)
+	}
+	for _, review := range pr.Reviews.Nodes {
+		commenters = append(commenters, review.Author.Login)
+	}
+	return commenters
+}
+
+func issueCommenters(issue data.IssueData) []string {
+	commenters := make([]string, 0, len(issue.Comments.Nodes))
+	for _, comment := range issue.Comments.Nodes {
+		commenters = append(commenters, comment.Author.Login)
+	}
+	return commenters
+}
+
+func labelNames(labels []data.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+)
+
+func TestPullRequestMatchesResidualFilter(t *testing.T) {
+	pr := data.PullRequestData{
+		Number:    1,
+		State:     "OPEN",
+		UpdatedAt: time.Now(),
+		Labels:    data.PRLabels{Nodes: []data.Label{{Name: "wip"}}},
+	}
+	item := NewPullRequestFromDataWithProvider(pr, "gitlab:gitlab.com")
+
+	expr, err := dsl.ParseFilter(`state = "open" and label != "wip"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	matched, err := dsl.Evaluate(expr, item, time.Now())
+	if err != nil {
+		t.Fatalf("evaluate error: %v", err)
+	}
+	if matched {
+		t.Fatalf("expected wip PR to be filtered out")
+	}
+}
+
+func TestIssueWithoutDataHasNoFilterFields(t *testing.T) {
+	if _, ok := (Issue{}).FilterField("state"); ok {
+		t.Fatalf("expected an issue without data to have no fields")
+	}
+	var issue Issue
+	issue.Data.Number = 1
+	issue.Data.State = "OPEN"
+	if state, ok := issue.FilterField("state"); !ok || state != "OPEN" {
+		t.Fatalf("unexpected state %v", state)
+	}
+}
+
+func TestPullRequestMatchesReviewRequestedAndInvolves(t *testing.T) {
+	pr := data.PullRequestData{Number: 1}
+	pr.Author.Login = "alice"
+	var user, team data.ReviewRequest
+	user.RequestedReviewer.User.Login = "bob"
+	team.RequestedReviewer.Team.Slug = "core"
+	team.RequestedReviewer.Team.Organization.Login = "org"
+	pr.ReviewRequests.Nodes = []data.ReviewRequest{user, team}
+	pr.Comments.Nodes = make([]struct{ Author struct{ Login string } }, 1)
+	pr.Comments.Nodes[0].Author.Login = "carol"
+	item := NewPullRequestFromDataWithProvider(pr, "github:github.com")
+
+	for filter, want := range map[string]bool{
+		`review_requested = "bob"`:                true,
+		`review_requested = "org/core"`:           true,
+		`not review_requested = "bob"`:            false,
+		`review_requested = "alice"`:              false,
+		`involves = "alice"`:                      true,
+		`involves = "carol"`:                      true,
+		`involves in ["dave", "bob"]`:             false,
+		`not (involves = "dave" or draft = true)`: true,
+	} {
+		expr, err := dsl.ParseFilter(filter)
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		matched, err := dsl.Evaluate(expr, item, time.Now())
+		if err != nil {
+			t.Fatalf("evaluate %s: %v", filter, err)
+		}
+		if matched != want {
+			t.Errorf("expected %s to be %v", filter, want)
+		}
+	}
+}
+
+func TestIssueMatchesReviewRequestedAndInvolves(t *testing.T) {
+	var issue Issue
+	issue.Data.Number = 1
+	issue.Data.Author.Login = "alice"
+	issue.Data.Assignees.Nodes = []data.Assignee{{Login: "bob"}}
+	issue.Data.Comments.Nodes = []data.IssueComment{{}}
+	issue.Data.Comments.Nodes[0].Author.Login = "carol"
+
+	for filter, want := range map[string]bool{
+		`review_requested = "bob"`: false,
+		`involves = "alice"`:       true,
+		`involves = "bob"`:         true,
+		`involves = "carol"`:       true,
+		`involves = "dave"`:        false,
+	} {
+		expr, err := dsl.ParseFilter(filter)
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		matched, err := dsl.Evaluate(expr, issue, time.Now())
+		if err != nil {
+			t.Fatalf("evaluate %s: %v", filter, err)
+		}
+		if matched != want {
+			t.Errorf("expected %s to be %v", filter, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+// Store keeps the latest copy of every pull request and issue the dashboard
+// shows, keyed by WorkItemKey. Sections record what they fetch in it and
+// sync their rows from it, so an update or enrichment of an item shows in
+// every section listing it. Items no section shows any more are dropped.
+type Store struct {
+	prs    map[WorkItemKey]PullRequest
+	issues map[WorkItemKey]Issue
+	// prRows and issueRows are the keys of the rows of each section, by
+	// section ID.
+	prRows    map[int][]WorkItemKey
+	issueRows map[int][]WorkItemKey
+}
+
+func NewStore() *Store {
+	return &Store{
+		prs:       map[WorkItemKey]PullRequest{},
+		issues:    map[WorkItemKey]Issue{},
+		prRows:    map[int][]WorkItemKey{},
+		issueRows: map[int][]WorkItemKey{},
+	}
+}
+
+// PutPullRequests records prs as the latest data of those pull requests and
+// returns them as stored. Enrichment is kept as long as a pull request
+// hasn't been updated since it was enriched.
+func (s *Store) PutPullRequests(prs []PullRequest) []PullRequest {
+	stored := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		key := pr.Key()
+		if old, ok := s.prs[key]; ok && old.IsEnriched && !pr.IsEnriched &&
+			old.GetUpdatedAt().Equal(pr.GetUpdatedAt()) {
+			pr.Enriched = old.Enriched
+			pr.IsEnriched = true
+		}
+		s.prs[key] = pr
+		stored = append(stored, pr)
+	}
+	return stored
+}
+
+// RetainPullRequests records prs as the rows of the pull request section
+// with sectionID and drops the pull requests no section has as rows any
+// more.
+func (s *Store) RetainPullRequests(sectionID int, prs []PullRequest) {
+	s.prRows[sectionID] = keys(prs)
+	retainRows(s.prs, s.prRows)
+}
+
+// KeepPullRequestSections forgets the rows of the pull request sections
+// other than sectionIDs, which a config reload removed, and drops the pull
+// requests only they had.
+func (s *Store) KeepPullRequestSections(sectionIDs []int) {
+	keepSections(s.prRows, sectionIDs)
+	retainRows(s.prs, s.prRows)
+}
+
+// UpdatePullRequest applies update to the stored pull request with key and
+// reports whether there is one.
+func (s *Store) UpdatePullRequest(key WorkItemKey, update func(pr *PullRequest)) bool {
+	pr, ok := s.prs[key]
+	if !ok || pr.Primary == nil {
+		return false
+	}
+	// rows still point at the old data until they sync, so the slices an
+	// update may append to are copied along with the primary data
+	primary := *pr.Primary
+	primary.Assignees.Nodes = slices.Clone(primary.Assignees.Nodes)
+	primary.Labels.Nodes = slices.Clone(primary.Labels.Nodes)
+	pr.Primary = &primary
+	pr.Enriched.Comments.Nodes = slices.Clone(pr.Enriched.Comments.Nodes)
+	pr.Enriched.ReviewThreads.Nodes = slices.Clone(pr.Enriched.ReviewThreads.Nodes)
+	update(&pr)
+	s.prs[key] = pr
+	return true
+}
+
+// EnrichPullRequest records the enriched data of the pull request with key.
+func (s *Store) EnrichPullRequest(key WorkItemKey, enriched data.EnrichedPullRequestData) bool {
+	return s.UpdatePullRequest(key, func(pr *PullRequest) {
+		pr.Enriched = enriched
+		pr.IsEnriched = true
+	})
+}
+
+// SyncPullRequests replaces prs with their stored versions.
+func (s *Store) SyncPullRequests(prs []PullRequest) {
+	for i, pr := range prs {
+		if stored, ok := s.prs[pr.Key()]; ok {
+			prs[i] = stored
+		}
+	}
+}
+
+// PutIssues records issues as the latest data of those issues and returns
+// them as stored.
+func (s *Store) PutIssues(issues []Issue) []Issue {
+	for _, issue := range issues {
+		s.issues[issue.Key()] = issue
+	}
+	return issues
+}
+
+// RetainIssues is the issue counterpart of RetainPullRequests.
+func (s *Store) RetainIssues(sectionID int, issues []Issue) {
+	s.issueRows[sectionID] = keys(issues)
+	retainRows(s.issues, s.issueRows)
+}
+
+// KeepIssueSections is the issue counterpart of KeepPullRequestSections.
+func (s *Store) KeepIssueSections(sectionIDs []int) {
+	keepSections(s.issueRows, sectionIDs)
+	retainRows(s.issues, s.issueRows)
+}
+
+// UpdateIssue applies update to the stored issue with key and reports
+// whether there is one.
+func (s *Store) UpdateIssue(key WorkItemKey, update func(issue *Issue)) bool {
+	issue, ok := s.issues[key]
+	if !ok {
+		return false
+	}
+	issue.Data.Assignees.Nodes = slices.Clone(issue.Data.Assignees.Nodes)
+	issue.Data.Labels.Nodes = slices.Clone(issue.Data.Labels.Nodes)
+	issue.Data.Comments.Nodes = slices.Clone(issue.Data.Comments.Nodes)
+	update(&issue)
+	s.issues[key] = issue
+	return true
+}
+
+// SyncIssues replaces issues with their stored versions.
+func (s *Store) SyncIssues(issues []Issue) {
+	for i, issue := range issues {
+		if stored, ok := s.issues[issue.Key()]; ok {
+			issues[i] = stored
+		}
+	}
+}
+
+func keys[T interface{ Key() WorkItemKey }](items []T) []WorkItemKey {
+	keys := make([]WorkItemKey, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key())
+	}
+	return keys
+}
+
+func keepSections(rows map[int][]WorkItemKey, sectionIDs []int) {
+	maps.DeleteFunc(rows, func(id int, _ []WorkItemKey) bool {
+		return !slices.Contains(sectionIDs, id)
+	})
+}
+
+// retainRows drops the items whose key isn't among the rows of any section.
+func retainRows[T any](items map[WorkItemKey]T, rows map[int][]WorkItemKey) {
+	shown := map[WorkItemKey]bool{}
+	for _, keys := range rows {
+		for _, key := range keys {
+			shown[key] = true
+		}
+	}
+	maps.DeleteFunc(items, func(key WorkItemKey, _ T) bool {
+		return !shown[key]
+	})
+}
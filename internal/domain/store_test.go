@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func TestStoreSharesUpdatesAndEnrichment(t *testing.T) {
+	updatedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	newPR := func() PullRequest {
+		return NewPullRequestFromDataWithProvider(data.PullRequestData{
+			Number:     7,
+			State:      "OPEN",
+			UpdatedAt:  updatedAt,
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, "github:github.com")
+	}
+	store := NewStore()
+	first := store.PutPullRequests([]PullRequest{newPR()})
+	second := store.PutPullRequests([]PullRequest{newPR()})
+
+	key := first[0].Key()
+	if !store.EnrichPullRequest(key, data.EnrichedPullRequestData{Url: "https://github.com/acme/app/pull/7"}) {
+		t.Fatalf("expected the pull request to be stored")
+	}
+	store.UpdatePullRequest(key, func(pr *PullRequest) {
+		pr.Primary.State = "CLOSED"
+	})
+	if second[0].Primary.State != "OPEN" {
+		t.Fatalf("expected rows to keep their data until they sync")
+	}
+
+	store.SyncPullRequests(first)
+	store.SyncPullRequests(second)
+	for _, rows := range [][]PullRequest{first, second} {
+		if rows[0].Primary.State != "CLOSED" || !rows[0].IsEnriched {
+			t.Fatalf("expected the update and enrichment in every copy, got %+v", rows[0])
+		}
+	}
+
+	refetched := store.PutPullRequests([]PullRequest{newPR()})
+	if !refetched[0].IsEnriched {
+		t.Fatalf("expected enrichment to be kept for an unchanged pull request")
+	}
+	changed := newPR()
+	changed.Primary.UpdatedAt = updatedAt.Add(time.Minute)
+	if store.PutPullRequests([]PullRequest{changed})[0].IsEnriched {
+		t.Fatalf("expected enrichment to be dropped once the pull request was updated")
+	}
+}
+
+func TestStoreDropsItemsNoSectionShows(t *testing.T) {
+	newPR := func(number int) PullRequest {
+		return NewPullRequestFromDataWithProvider(data.PullRequestData{
+			Number:     number,
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, "github:github.com")
+	}
+	store := NewStore()
+	first := store.PutPullRequests([]PullRequest{newPR(1), newPR(2)})
+	store.RetainPullRequests(1, first)
+	second := store.PutPullRequests([]PullRequest{newPR(2)})
+	store.RetainPullRequests(2, second)
+
+	store.RetainPullRequests(1, store.PutPullRequests([]PullRequest{newPR(3)}))
+	if store.UpdatePullRequest(first[0].Key(), func(*PullRequest) {}) {
+		t.Fatalf("expected a pull request no section shows to be dropped")
+	}
+	if !store.UpdatePullRequest(second[0].Key(), func(*PullRequest) {}) {
+		t.Fatalf("expected a pull request another section shows to be kept")
+	}
+
+	store.RetainPullRequests(2, nil)
+	if store.UpdatePullRequest(second[0].Key(), func(*PullRequest) {}) {
+		t.Fatalf("expected the pull request to be dropped once no section shows it")
+	}
+
+	var issue Issue
+	issue.Data.Number = 4
+	store.RetainIssues(1, store.PutIssues([]Issue{issue}))
+	store.RetainIssues(1, nil)
+	if store.UpdateIssue(issue.Key(), func(*Issue) {}) {
+		t.Fatalf("expected an issue no section shows to be dropped")
+	}
+}
+
+func TestStoreForgetsRemovedSections(t *testing.T) {
+	var issue Issue
+	issue.Data.Number = 1
+	store := NewStore()
+	store.RetainIssues(0, nil)
+	store.RetainIssues(3, store.PutIssues([]Issue{issue}))
+
+	store.KeepIssueSections([]int{0, 1, 2})
+	if store.UpdateIssue(issue.Key(), func(*Issue) {}) {
+		t.Fatalf("expected the issues of a removed section to be dropped")
+	}
+	if _, ok := store.issueRows[0]; !ok {
+		t.Fatalf("expected the rows of a kept section to be kept")
+	}
+}
+
+func TestStoreUpdatesDontChangeRows(t *testing.T) {
+	pr := NewPullRequestFromDataWithProvider(data.PullRequestData{
+		Number:     1,
+		Repository: data.Repository{NameWithOwner: "acme/app"},
+	}, "github:github.com")
+	pr.Enriched.Comments.Nodes = make([]data.Comment, 1, 4)
+	store := NewStore()
+	rows := store.PutPullRequests([]PullRequest{pr})
+
+	store.UpdatePullRequest(pr.Key(), func(pr *PullRequest) {
+		pr.Enriched.Comments.Nodes = append(pr.Enriched.Comments.Nodes, data.Comment{Body: "new"})
+		pr.Primary.Assignees.Nodes = append(pr.Primary.Assignees.Nodes, data.Assignee{Login: "alice"})
+	})
+	if got := rows[0].Enriched.Comments.Nodes[:2][1].Body; got != "" {
+		t.Fatalf("expected the row's comments to be unchanged, got %q", got)
+	}
+	if len(rows[0].Primary.Assignees.Nodes) != 0 {
+		t.Fatalf("expected the row's assignees to be unchanged")
+	}
+}
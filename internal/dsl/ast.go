@@ -25,6 +25,10 @@ const (
 	OpGte CompareOp = ">="
 	OpLt  CompareOp = "<"
 	OpLte CompareOp = "<="
+	// OpMatch matches a regular expression and OpContains a case-insensitive
+	// substring. Both only apply to text fields.
+	OpMatch    CompareOp = "~"
+	OpContains CompareOp = "contains"
 )
 
 type MembershipOp string
@@ -58,6 +62,45 @@ type PredicateExpr struct {
 
 func (PredicateExpr) exprNode() {}
 
+// MacroExpr references a named filter from the config. ParseFilter replaces
+// it with the parsed definition.
+type MacroExpr struct {
+	Name string
+}
+
+func (MacroExpr) exprNode() {}
+
+type SortField string
+
+const (
+	SortUpdated   SortField = "updated"
+	SortCreated   SortField = "created"
+	SortComments  SortField = "comments"
+	SortReactions SortField = "reactions"
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+type OrderBy struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// OrderedExpr wraps a filter that ends with an `order by` clause. It only
+// appears at the root of a parsed filter; Expr is nil for a filter that
+// consists of the clause alone.
+type OrderedExpr struct {
+	Expr  Expr
+	Order OrderBy
+}
+
+func (OrderedExpr) exprNode() {}
+
 type Value interface {
 	valueNode()
 	String() string
@@ -106,6 +149,41 @@ func (v DateValue) String() string {
 	return v.Value.Format("2006-01-02")
 }
 
+// DateTimeValue is a point in time such as 2026-01-05T09:00:00+01:00.
+type DateTimeValue struct {
+	Value time.Time
+}
+
+func (DateTimeValue) valueNode() {}
+
+func (v DateTimeValue) String() string {
+	return v.Value.Format(time.RFC3339)
+}
+
+// CalendarValue is a calendar period relative to the time the filter is
+// evaluated, such as today or this_week. Weeks start on Monday.
+type CalendarValue struct {
+	Name string
+}
+
+func (CalendarValue) valueNode() {}
+
+func (v CalendarValue) String() string {
+	return v.Name
+}
+
+// RangeValue covers everything from the start of From to the end of To.
+type RangeValue struct {
+	From Value
+	To   Value
+}
+
+func (RangeValue) valueNode() {}
+
+func (v RangeValue) String() string {
+	return fmt.Sprintf("between(%s, %s)", v.From.String(), v.To.String())
+}
+
 type DurationValue struct {
 	Value time.Duration
 }
@@ -144,3 +222,15 @@ func (FunctionValue) valueNode() {}
 func (v FunctionValue) String() string {
 	return fmt.Sprintf("%s(%s)", v.Name, v.Arg.String())
 }
+
+// TeamValue stands for the members of a team, such as team("acme/backend").
+// It is resolved per provider before a filter is translated.
+type TeamValue struct {
+	Slug string
+}
+
+func (TeamValue) valueNode() {}
+
+func (v TeamValue) String() string {
+	return fmt.Sprintf("team(%q)", v.Slug)
+}
@@ -0,0 +1,338 @@
+package dsl
+
+import (
+	"sort"
+	"strings"
+)
+
+// Completion lists what can be written at a cursor. Start and End are byte
+// offsets of the partly typed word the items replace.
+type Completion struct {
+	Start int
+	End   int
+	Items []string
+}
+
+type completionState int
+
+const (
+	expectTerm completionState = iota
+	expectOperator
+	expectIn
+	expectValue
+	expectMembership
+	expectListValue
+	afterListValue
+	afterTerm
+	expectBy
+	expectSortField
+	expectDirection
+	completionDone
+)
+
+// Complete suggests field names, operators, keywords and values for the
+// word at cursor in input. values holds extra candidates per field, such as
+// labels seen in loaded items; they are quoted as needed. The macros in defs
+// are suggested where a predicate can go.
+func Complete(input string, cursor int, values map[string][]string, defs map[string]string) Completion {
+	cursor = min(max(cursor, 0), len(input))
+	completion := Completion{Start: cursor, End: cursor}
+
+	l := newLexer(input[:cursor])
+	var tokens []token
+	partial := ""
+	for {
+		l.skipWhitespace()
+		start := l.pos
+		tok, err := l.nextToken()
+		if err != nil {
+			// an open string or a lone @ is the word being typed
+			rest := string(l.input[start:])
+			if !strings.HasPrefix(rest, `"`) && rest != "@" {
+				return completion
+			}
+			partial = rest
+			completion.Start = l.offset(start)
+			break
+		}
+		if tok.typ == tokenEOF {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	var word *token
+	if partial == "" && len(tokens) > 0 {
+		last := tokens[len(tokens)-1]
+		if last.end == len(l.input) && isPartialToken(last) {
+			word = &last
+			tokens = tokens[:len(tokens)-1]
+			completion.Start = l.offset(last.pos)
+			partial = string(l.input[last.pos:last.end])
+		}
+	}
+
+	items, exact := completionItems(tokens, partial, values, defs)
+	if len(items) == 0 && exact && word != nil {
+		// the word is already complete, so complete what comes after it
+		completion.Start = cursor
+		items, _ = completionItems(append(tokens, *word), "", values, defs)
+	}
+	completion.Items = items
+	return completion
+}
+
+// completionItems returns the candidates for the next token after tokens
+// that start with partial, and whether partial is a candidate itself.
+func completionItems(tokens []token, partial string, values map[string][]string, defs map[string]string) ([]string, bool) {
+	state, field := completionContext(tokens)
+	var candidates []string
+	switch state {
+	case expectTerm:
+		candidates = append(FieldNames(), "not")
+		for _, name := range sortedMacroNames(defs) {
+			candidates = append(candidates, "@"+name)
+		}
+		if len(tokens) == 0 {
+			candidates = append(candidates, "order by")
+		}
+	case expectOperator:
+		candidates = operatorNames
+		if schema, ok := LookupField(field); ok {
+			candidates = schema.Ops
+		}
+	case expectIn:
+		candidates = []string{"in"}
+	case expectValue, expectMembership, expectListValue:
+		candidates = valueCandidates(field, values)
+		if state == expectMembership {
+			schema, _ := LookupField(field)
+			switch {
+			case schema.Type == TypeTime:
+			case schema.Teams:
+				candidates = []string{"[", "team("}
+			default:
+				candidates = []string{"["}
+			}
+		}
+	case afterListValue:
+		candidates = []string{",", "]"}
+	case afterTerm:
+		candidates = []string{"and", "or", "order by"}
+	case expectBy:
+		candidates = []string{"by"}
+	case expectSortField:
+		candidates = sortFieldNames
+	case expectDirection:
+		candidates = []string{string(SortAsc), string(SortDesc)}
+	}
+
+	var items []string
+	exact := false
+	lower := strings.ToLower(partial)
+	for _, candidate := range candidates {
+		switch {
+		case strings.EqualFold(candidate, partial):
+			exact = true
+		case strings.HasPrefix(strings.ToLower(candidate), lower):
+			items = append(items, candidate)
+		}
+	}
+	return items, exact
+}
+
+// Apply replaces the completed word in input with item and returns the new
+// input with the cursor position after it.
+func (c Completion) Apply(input, item string) (string, int) {
+	before := input[:c.Start]
+	after := input[c.End:]
+	if c.Start == c.End && before != "" && !strings.ContainsAny(before[len(before)-1:], " ([") &&
+		!strings.ContainsAny(item[:1], ",])") {
+		before += " "
+	}
+	suffix := " "
+	if strings.HasSuffix(item, "(") || strings.HasSuffix(item, "[") || strings.HasPrefix(after, " ") {
+		suffix = ""
+	}
+	out := before + item + suffix
+	return out + after, len(out)
+}
+
+func isPartialToken(tok token) bool {
+	switch tok.typ {
+	case tokenIdent, tokenMacro, tokenBool, tokenNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+// completionContext walks the tokens before the cursor and returns what the
+// grammar expects next, along with the field of the predicate being written.
+func completionContext(tokens []token) (completionState, string) {
+	state := expectTerm
+	field := ""
+	// call is set after last, between, team and use; depth counts the
+	// parentheses of the call being skipped.
+	call := false
+	depth := 0
+	for _, tok := range tokens {
+		lit := strings.ToLower(tok.lit)
+		if call {
+			if tok.typ != tokenLParen {
+				return completionDone, ""
+			}
+			call = false
+			depth = 1
+			continue
+		}
+		if depth > 0 {
+			switch tok.typ {
+			case tokenLParen:
+				depth++
+			case tokenRParen:
+				depth--
+			}
+			continue
+		}
+		switch state {
+		case expectTerm:
+			switch {
+			case tok.typ == tokenNot || tok.typ == tokenLParen || (tok.typ == tokenOp && tok.lit == "!"):
+			case tok.typ == tokenMacro:
+				state = afterTerm
+			case tok.typ == tokenIdent && lit == "use":
+				state, call = afterTerm, true
+			case tok.typ == tokenIdent && lit == "order":
+				state = expectBy
+			case tok.typ == tokenIdent:
+				state, field = expectOperator, lit
+			default:
+				return completionDone, ""
+			}
+		case expectOperator:
+			switch {
+			case tok.typ == tokenOp || (tok.typ == tokenIdent && lit == string(OpContains)):
+				state = expectValue
+			case tok.typ == tokenIn:
+				state = expectMembership
+			case tok.typ == tokenNot:
+				state = expectIn
+			default:
+				return completionDone, ""
+			}
+		case expectIn:
+			if tok.typ != tokenIn {
+				return completionDone, ""
+			}
+			state = expectMembership
+		case expectValue, expectMembership:
+			switch {
+			case tok.typ == tokenLBracket && state == expectMembership:
+				state = expectListValue
+			case tok.typ == tokenIdent && (lit == "last" || lit == "between" || lit == "team"):
+				state, call = afterTerm, true
+			default:
+				state = afterTerm
+			}
+		case expectListValue:
+			switch {
+			case tok.typ == tokenRBracket:
+				state = afterTerm
+			case tok.typ == tokenIdent && lit == "team":
+				state, call = afterListValue, true
+			default:
+				state = afterListValue
+			}
+		case afterListValue:
+			switch tok.typ {
+			case tokenComma:
+				state = expectListValue
+			case tokenRBracket:
+				state = afterTerm
+			default:
+				return completionDone, ""
+			}
+		case afterTerm:
+			switch {
+			case tok.typ == tokenAnd || tok.typ == tokenOr:
+				state = expectTerm
+			case tok.typ == tokenRParen:
+			case tok.typ == tokenIdent && lit == "order":
+				state = expectBy
+			default:
+				return completionDone, ""
+			}
+		case expectBy:
+			if tok.typ != tokenIdent || lit != "by" {
+				return completionDone, ""
+			}
+			state = expectSortField
+		case expectSortField:
+			state = expectDirection
+		default:
+			return completionDone, ""
+		}
+	}
+	if call || depth > 0 {
+		return completionDone, ""
+	}
+	return state, field
+}
+
+func valueCandidates(field string, values map[string][]string) []string {
+	var candidates []string
+	schema, _ := LookupField(field)
+	switch {
+	case schema.Type == TypeBool:
+		candidates = []string{"true", "false"}
+	case schema.Type == TypeTime:
+		candidates = append([]string{"last(", "between("}, calendarNames...)
+	case len(schema.Values) > 0:
+		for _, value := range schema.Values {
+			candidates = append(candidates, quoteString(value))
+		}
+	case field == "state":
+		// states differ between providers, so the schema doesn't restrict them
+		candidates = []string{`"open"`, `"closed"`, `"merged"`}
+	}
+	_, isUserField := userFields[field]
+	if isUserField {
+		candidates = []string{`"me"`}
+	}
+	if schema.Teams {
+		candidates = append(candidates, "team(")
+	}
+
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		seen[candidate] = true
+	}
+	extra := values[field]
+	if isUserField {
+		// any user seen in one role is a likely candidate for the others
+		for other := range userFields {
+			if other != field {
+				extra = append(extra, values[other]...)
+			}
+		}
+	}
+	var quoted []string
+	for _, value := range extra {
+		value = quoteString(value)
+		if !seen[value] {
+			seen[value] = true
+			quoted = append(quoted, value)
+		}
+	}
+	sort.Strings(quoted)
+	return append(candidates, quoted...)
+}
+
+func sortedMacroNames(defs map[string]string) []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
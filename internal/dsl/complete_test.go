@@ -0,0 +1,83 @@
+package dsl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	values := map[string][]string{
+		"label":    {"ui", "bug"},
+		"author":   {"alice"},
+		"assignee": {"bob"},
+		"provider": {"github:github.com", "github"},
+	}
+	tests := []struct {
+		input      string
+		start, end int
+		items      []string
+	}{
+		{input: `st`, start: 0, end: 2, items: []string{"state"}},
+		{input: `state `, start: 6, end: 6, items: []string{"=", "!=", "in", "not in"}},
+		{input: `title co`, start: 6, end: 8, items: []string{"contains"}},
+		{input: `title contains `, start: 15, end: 15},
+		{input: `state = "o`, start: 8, end: 10, items: []string{`"open"`}},
+		{input: `label in `, start: 9, end: 9, items: []string{"["}},
+		{input: `label in [`, start: 10, end: 10, items: []string{`"bug"`, `"ui"`}},
+		{input: `label in ["bug"`, start: 15, end: 15, items: []string{",", "]"}},
+		{input: `author = `, start: 9, end: 9, items: []string{`"me"`, "team(", `"alice"`, `"bob"`}},
+		{input: `author in `, start: 10, end: 10, items: []string{"[", "team("}},
+		{input: `author in team("acme/backend") `, start: 31, end: 31, items: []string{"and", "or", "order by"}},
+		{input: `assignee in [team("acme/backend"), `, start: 35, end: 35, items: []string{`"me"`, "team(", `"alice"`, `"bob"`}},
+		{input: `provider in ["gi`, start: 13, end: 16, items: []string{`"github"`, `"github:github.com"`}},
+		{input: `draft = t`, start: 8, end: 9, items: []string{"true"}},
+		{input: `updated in th`, start: 11, end: 13, items: []string{"this_week", "this_month"}},
+		{input: `state = "open" an`, start: 15, end: 17, items: []string{"and"}},
+		{input: `state = "open" order by `, start: 24, end: 24, items: sortFieldNames},
+		{input: `state = "open" order by updated d`, start: 32, end: 33, items: []string{"desc"}},
+		{input: `updated in last(`, start: 16, end: 16},
+		{input: `(dr`, start: 1, end: 3, items: []string{"draft"}},
+	}
+	for _, tt := range tests {
+		got := Complete(tt.input, len(tt.input), values, nil)
+		if got.Start != tt.start || got.End != tt.end {
+			t.Fatalf("%q: unexpected range %d-%d", tt.input, got.Start, got.End)
+		}
+		if !reflect.DeepEqual(got.Items, tt.items) {
+			t.Fatalf("%q: unexpected items %v, want %v", tt.input, got.Items, tt.items)
+		}
+	}
+}
+
+func TestCompleteMacros(t *testing.T) {
+	got := Complete(`state = "open" and @`, 20, nil, map[string]string{"mine": `author = "me"`})
+	if !reflect.DeepEqual(got.Items, []string{"@mine"}) {
+		t.Fatalf("unexpected items %v", got.Items)
+	}
+}
+
+func TestCompletionApply(t *testing.T) {
+	tests := []struct {
+		input  string
+		cursor int
+		item   string
+		want   string
+	}{
+		{input: `st`, cursor: 2, item: "state", want: `state `},
+		{input: `state`, cursor: 5, item: "=", want: `state = `},
+		{input: `label in [`, cursor: 10, item: `"bug"`, want: `label in ["bug" `},
+		{input: `label in ["bug"`, cursor: 15, item: ",", want: `label in ["bug", `},
+		{input: `updated in `, cursor: 11, item: "last(", want: `updated in last(`},
+		{input: `sta and draft = true`, cursor: 3, item: "state", want: `state and draft = true`},
+	}
+	for _, tt := range tests {
+		completion := Complete(tt.input, tt.cursor, nil, nil)
+		got, cursor := completion.Apply(tt.input, tt.item)
+		if got != tt.want {
+			t.Fatalf("%q: got %q, want %q", tt.input, got, tt.want)
+		}
+		if cursor > len(got) {
+			t.Fatalf("%q: cursor %d past the end", tt.input, cursor)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package dsl
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+var calendarNames = []string{"today", "yesterday", "this_week", "last_week", "this_month", "last_month"}
+
+func isCalendarName(name string) bool {
+	return slices.Contains(calendarNames, name)
+}
+
+// timeSpan is the half-open interval [start, end) a date value covers. Points
+// in time such as date-times and durations cover a single instant.
+type timeSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+func instantSpan(t time.Time) timeSpan {
+	return timeSpan{start: t, end: t.Add(time.Nanosecond)}
+}
+
+func (span timeSpan) instant() bool {
+	return span.end.Sub(span.start) == time.Nanosecond
+}
+
+// last returns the last whole second inside span.
+func (span timeSpan) last() time.Time {
+	if span.instant() {
+		return span.start
+	}
+	return span.end.Add(-time.Second)
+}
+
+func resolveTimeSpan(value Value, now time.Time) (timeSpan, error) {
+	switch val := value.(type) {
+	case DateValue:
+		return timeSpan{start: val.Value, end: val.Value.AddDate(0, 0, 1)}, nil
+	case DateTimeValue:
+		return instantSpan(val.Value), nil
+	case DurationValue:
+		return instantSpan(now.Add(val.Value)), nil
+	case CalendarValue:
+		return calendarSpan(val.Name, now)
+	case RangeValue:
+		from, err := resolveTimeSpan(val.From, now)
+		if err != nil {
+			return timeSpan{}, err
+		}
+		to, err := resolveTimeSpan(val.To, now)
+		if err != nil {
+			return timeSpan{}, err
+		}
+		if to.end.Before(from.start) {
+			return timeSpan{}, fmt.Errorf("%s ends before it starts", val.String())
+		}
+		return timeSpan{start: from.start, end: to.end}, nil
+	default:
+		return timeSpan{}, fmt.Errorf("expected date or duration")
+	}
+}
+
+// calendarSpan resolves name in now's location, so "today" starts at the
+// local midnight.
+func calendarSpan(name string, now time.Time) (timeSpan, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	switch name {
+	case "today":
+		return timeSpan{start: today, end: today.AddDate(0, 0, 1)}, nil
+	case "yesterday":
+		return timeSpan{start: today.AddDate(0, 0, -1), end: today}, nil
+	case "this_week":
+		return timeSpan{start: weekStart, end: weekStart.AddDate(0, 0, 7)}, nil
+	case "last_week":
+		return timeSpan{start: weekStart.AddDate(0, 0, -7), end: weekStart}, nil
+	case "this_month":
+		return timeSpan{start: monthStart, end: monthStart.AddDate(0, 1, 0)}, nil
+	case "last_month":
+		return timeSpan{start: monthStart.AddDate(0, -1, 0), end: monthStart}, nil
+	default:
+		return timeSpan{}, fmt.Errorf("unknown calendar value %q", name)
+	}
+}
+
+func isSpanValue(value Value) bool {
+	switch value.(type) {
+	case DateTimeValue, CalendarValue, RangeValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// githubSpanBounds formats the first and last moment of span for GitHub's
+// search syntax. Boundaries on a UTC midnight are written as plain dates;
+// anything else keeps its time and offset.
+func githubSpanBounds(span timeSpan) (string, string) {
+	if span.instant() {
+		formatted := span.start.Format(time.RFC3339)
+		return formatted, formatted
+	}
+	first := span.start.Format(time.RFC3339)
+	if isUTCMidnight(span.start) {
+		first = span.start.UTC().Format("2006-01-02")
+	}
+	last := span.last().Format(time.RFC3339)
+	if isUTCMidnight(span.end) {
+		last = span.end.UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return first, last
+}
+
+func isUTCMidnight(t time.Time) bool {
+	utc := t.UTC()
+	return utc.Hour() == 0 && utc.Minute() == 0 && utc.Second() == 0 && utc.Nanosecond() == 0
+}
+
+func formatGitLabTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
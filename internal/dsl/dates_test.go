@@ -0,0 +1,188 @@
+package dsl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFilterDateTime(t *testing.T) {
+	expr, err := ParseFilter(`updated >= 2026-01-05T09:30:00+01:00`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	value, ok := expr.(PredicateExpr).Value.(DateTimeValue)
+	if !ok {
+		t.Fatalf("expected date-time, got %#v", expr.(PredicateExpr).Value)
+	}
+	want := time.Date(2026, 1, 5, 8, 30, 0, 0, time.UTC)
+	if !value.Value.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, value.Value)
+	}
+
+	for _, filter := range []string{
+		`updated > 2026-01-05T09:30Z`,
+		`updated > 2026-01-05t09:30:00z`,
+		`created < 2026-01-05T09:30:00-05:00`,
+		`created = between(2026-01-01, -7d)`,
+		`updated in this_week`,
+		`updated not in between(last_month, yesterday)`,
+	} {
+		if _, err := ParseFilter(filter); err != nil {
+			t.Fatalf("parse error for %q: %v", filter, err)
+		}
+	}
+
+	for _, filter := range []string{
+		`updated > 2026-01-05T25:00Z`,
+		`updated = between(2026-01-01)`,
+		`updated = between("a", 2026-01-01)`,
+		`updated in next_week`,
+	} {
+		if _, err := ParseFilter(filter); err == nil {
+			t.Fatalf("expected error for %q", filter)
+		}
+	}
+}
+
+func TestTranslateCalendarValues(t *testing.T) {
+	// Sunday, the last day of both a week and a month.
+	now := time.Date(2026, 5, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		filter string
+		github string
+		after  string
+		before string
+	}{
+		{
+			filter: `updated = today`,
+			github: "updated:2026-05-31",
+			after:  "2026-05-31T00:00:00Z",
+			before: "2026-05-31T23:59:59Z",
+		},
+		{
+			filter: `updated in this_week`,
+			github: "updated:2026-05-25..2026-05-31",
+			after:  "2026-05-25T00:00:00Z",
+			before: "2026-05-31T23:59:59Z",
+		},
+		{
+			filter: `updated = last_week`,
+			github: "updated:2026-05-18..2026-05-24",
+			after:  "2026-05-18T00:00:00Z",
+			before: "2026-05-24T23:59:59Z",
+		},
+		{
+			filter: `updated = this_month`,
+			github: "updated:2026-05-01..2026-05-31",
+			after:  "2026-05-01T00:00:00Z",
+			before: "2026-05-31T23:59:59Z",
+		},
+		{
+			filter: `updated = last_month`,
+			github: "updated:2026-04-01..2026-04-30",
+			after:  "2026-04-01T00:00:00Z",
+			before: "2026-04-30T23:59:59Z",
+		},
+		{
+			filter: `updated = between(2026-01-01, 2026-01-31)`,
+			github: "updated:2026-01-01..2026-01-31",
+			after:  "2026-01-01T00:00:00Z",
+			before: "2026-01-31T23:59:59Z",
+		},
+		{
+			filter: `updated > yesterday`,
+			github: "updated:>2026-05-30",
+			after:  "2026-05-31T00:00:00Z",
+		},
+		{
+			filter: `updated < this_week`,
+			github: "updated:<2026-05-25",
+			before: "2026-05-24T23:59:59Z",
+		},
+		{
+			filter: `updated >= 2026-05-31T08:00:00+02:00`,
+			github: "updated:>=2026-05-31T08:00:00+02:00",
+			after:  "2026-05-31T06:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		expr, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("parse error for %q: %v", tt.filter, err)
+		}
+		github, err := TranslateGitHub(expr, now)
+		if err != nil {
+			t.Fatalf("github translate error for %q: %v", tt.filter, err)
+		}
+		if github.Query != tt.github {
+			t.Fatalf("%s: expected github query %q, got %q", tt.filter, tt.github, github.Query)
+		}
+		gitlab, err := TranslateGitLab(expr, now)
+		if err != nil {
+			t.Fatalf("gitlab translate error for %q: %v", tt.filter, err)
+		}
+		if gitlab.Params["updated_after"] != tt.after || gitlab.Params["updated_before"] != tt.before {
+			t.Fatalf("%s: unexpected gitlab params %v", tt.filter, gitlab.Params)
+		}
+	}
+}
+
+func TestTranslateCalendarValuesInLocalTime(t *testing.T) {
+	// Monday morning in UTC+2 is still Sunday in UTC.
+	now := time.Date(2026, 6, 1, 1, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	expr, err := ParseFilter(`created = this_week`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	github, err := TranslateGitHub(expr, now)
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if github.Query != "created:2026-06-01T00:00:00+02:00..2026-06-07T23:59:59+02:00" {
+		t.Fatalf("unexpected github query: %q", github.Query)
+	}
+	gitlab, err := TranslateGitLab(expr, now)
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if gitlab.Params["created_after"] != "2026-05-31T22:00:00Z" || gitlab.Params["created_before"] != "2026-06-07T21:59:59Z" {
+		t.Fatalf("unexpected gitlab params: %v", gitlab.Params)
+	}
+}
+
+func TestEvaluateCalendarValues(t *testing.T) {
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	subject := fakeSubject{"updated": time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	tests := map[string]bool{
+		`updated = this_week`:                            true,
+		`updated = last_week`:                            false,
+		`updated in this_month`:                          true,
+		`updated not in between(2026-03-01, 2026-03-01)`: true,
+		`updated < today`:                                true,
+		`updated > 2026-03-02T08:59:59Z`:                 true,
+		`updated not in last(3d)`:                        true,
+	}
+	for filter, want := range tests {
+		expr, err := ParseFilter(filter)
+		if err != nil {
+			t.Fatalf("parse error for %q: %v", filter, err)
+		}
+		got, err := Evaluate(expr, subject, now)
+		if err != nil {
+			t.Fatalf("evaluate error for %q: %v", filter, err)
+		}
+		if got != want {
+			t.Fatalf("%s: expected %t, got %t", filter, want, got)
+		}
+	}
+}
+
+func TestBetweenEndsBeforeStart(t *testing.T) {
+	expr, err := ParseFilter(`updated = between(2026-02-01, 2026-01-01)`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if _, err := TranslateGitHub(expr, time.Now()); err == nil {
+		t.Fatalf("expected error for reversed range")
+	}
+}
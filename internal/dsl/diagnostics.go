@@ -0,0 +1,158 @@
+package dsl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Diagnostic is a problem located in a filter. Start and End are byte
+// offsets into the input; End is exclusive and equals Start at the end of
+// input.
+type Diagnostic struct {
+	Start       int
+	End         int
+	Message     string
+	Expected    []string
+	Suggestions []string
+}
+
+func (d Diagnostic) Error() string {
+	msg := fmt.Sprintf("%s at %d", d.Message, d.Start)
+	if len(d.Suggestions) > 0 {
+		msg += fmt.Sprintf("; did you mean %s?", strings.Join(d.Suggestions, " or "))
+	}
+	return msg
+}
+
+func (d Diagnostic) expecting(expected ...string) Diagnostic {
+	d.Expected = expected
+	return d
+}
+
+func (d Diagnostic) suggesting(suggestions ...string) Diagnostic {
+	d.Suggestions = suggestions
+	return d
+}
+
+// Diagnose returns the problems it can locate in input, ordered by
+// position: the syntax error that stopped parsing, if any, and the unknown
+// fields, mistyped predicates and undefined macros that come before it.
+// Every macro reference is reported as undefined.
+func Diagnose(input string) []Diagnostic {
+	return DiagnoseWithMacros(input, nil)
+}
+
+// DiagnoseWithMacros is Diagnose with the macro definitions in defs.
+func DiagnoseWithMacros(input string, defs map[string]string) []Diagnostic {
+	p := newParser(input)
+	expr, err := p.parseFilter()
+
+	var diagnostics []Diagnostic
+	for _, tok := range p.fields {
+		if IsKnownField(tok.lit) {
+			continue
+		}
+		if IsReserved(tok.lit) {
+			diagnostics = append(diagnostics, p.errorAt(tok, "%q is a reserved word, not a field", tok.lit).
+				expecting(fieldNames...))
+			continue
+		}
+		diagnostics = append(diagnostics, p.errorAt(tok, "unknown field %q", tok.lit).
+			expecting(fieldNames...).
+			suggesting(suggest(strings.ToLower(tok.lit), fieldNames)...))
+	}
+	for _, span := range p.predicates {
+		if diagnostic, ok := checkPredicate(span.expr); !ok {
+			diagnostic.Start, diagnostic.End = p.lexer.offset(span.pos), p.lexer.offset(span.end)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	undefined := false
+	for _, tok := range p.macros {
+		if _, ok := defs[tok.lit]; ok {
+			continue
+		}
+		undefined = true
+		diagnostics = append(diagnostics, p.errorAt(tok, "macro %q is not defined", tok.lit).
+			suggesting(suggest(tok.lit, names)...))
+	}
+
+	if err != nil {
+		var diagnostic Diagnostic
+		if !errors.As(err, &diagnostic) {
+			diagnostic = Diagnostic{End: len(input), Message: err.Error()}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	} else if !undefined {
+		if _, err := ExpandMacros(expr, defs); err != nil {
+			diagnostics = append(diagnostics, Diagnostic{End: len(input), Message: err.Error()})
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Start < diagnostics[j].Start
+	})
+	return diagnostics
+}
+
+// suggest returns the candidates closest to word, as long as they are close
+// enough to be a likely typo.
+func suggest(word string, candidates []string) []string {
+	word = strings.ToLower(word)
+	limit := 1
+	if len(word) >= 5 {
+		limit = 2
+	}
+	best := limit + 1
+	var out []string
+	for _, candidate := range candidates {
+		distance := editDistance(word, strings.ToLower(candidate))
+		switch {
+		case distance == 0:
+			return nil
+		case distance < best:
+			best = distance
+			out = []string{candidate}
+		case distance == best:
+			out = append(out, candidate)
+		}
+	}
+	return slices.Compact(out)
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters that turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
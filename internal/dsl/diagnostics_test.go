@@ -0,0 +1,103 @@
+package dsl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiagnoseSuggestsFieldNames(t *testing.T) {
+	diagnostics := Diagnose(`asignee = @me`)
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %+v", diagnostics)
+	}
+
+	field := diagnostics[0]
+	if field.Start != 0 || field.End != 7 {
+		t.Fatalf("unexpected range for unknown field: %d-%d", field.Start, field.End)
+	}
+	if !reflect.DeepEqual(field.Suggestions, []string{"assignee"}) {
+		t.Fatalf("unexpected suggestions: %v", field.Suggestions)
+	}
+
+	value := diagnostics[1]
+	if value.Start != 10 || value.End != 13 {
+		t.Fatalf("unexpected range for value: %d-%d", value.Start, value.End)
+	}
+	if !reflect.DeepEqual(value.Suggestions, []string{`"@me"`}) {
+		t.Fatalf("unexpected suggestions: %v", value.Suggestions)
+	}
+}
+
+func TestDiagnoseReservedWordAsField(t *testing.T) {
+	diagnostics := Diagnose(`today = "x"`)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %+v", diagnostics)
+	}
+	if !strings.Contains(diagnostics[0].Message, "reserved word") || len(diagnostics[0].Suggestions) != 0 {
+		t.Fatalf("unexpected diagnostic: %+v", diagnostics[0])
+	}
+}
+
+func TestDiagnoseSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		input      string
+		start, end int
+		suggestion string
+		expected   string
+	}{
+		{input: `state = "open" && draft = true`, start: 15, end: 16, suggestion: "and"},
+		{input: `author == "me"`, start: 7, end: 9, suggestion: "="},
+		{input: `label inn ["bug"]`, start: 6, end: 9, suggestion: "in"},
+		{input: `updated > yesterdy`, start: 10, end: 18, suggestion: "yesterday"},
+		{input: `state = "open" order by updatd`, start: 24, end: 30, suggestion: "updated"},
+		{input: `label in ["bug" "ui"]`, start: 16, end: 20, expected: "]"},
+		{input: `state = `, start: 8, end: 8, expected: "string"},
+	}
+	for _, tt := range tests {
+		diagnostics := Diagnose(tt.input)
+		if len(diagnostics) != 1 {
+			t.Fatalf("%s: expected 1 diagnostic, got %+v", tt.input, diagnostics)
+		}
+		got := diagnostics[0]
+		if got.Start != tt.start || got.End != tt.end {
+			t.Fatalf("%s: unexpected range %d-%d", tt.input, got.Start, got.End)
+		}
+		if tt.suggestion != "" && !reflect.DeepEqual(got.Suggestions, []string{tt.suggestion}) {
+			t.Fatalf("%s: unexpected suggestions %v", tt.input, got.Suggestions)
+		}
+		if tt.expected != "" && !strings.Contains(strings.Join(got.Expected, " "), tt.expected) {
+			t.Fatalf("%s: expected %q among %v", tt.input, tt.expected, got.Expected)
+		}
+	}
+}
+
+func TestDiagnoseUsesByteOffsets(t *testing.T) {
+	diagnostics := Diagnose(`text = "café" and lable = "bug"`)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %+v", diagnostics)
+	}
+	if diagnostics[0].Start != 19 || diagnostics[0].End != 24 {
+		t.Fatalf("unexpected range: %d-%d", diagnostics[0].Start, diagnostics[0].End)
+	}
+}
+
+func TestDiagnoseMacros(t *testing.T) {
+	defs := map[string]string{"mine": `author = "me"`}
+	diagnostics := DiagnoseWithMacros(`@mien and state = "open"`, defs)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %+v", diagnostics)
+	}
+	if !reflect.DeepEqual(diagnostics[0].Suggestions, []string{"mine"}) {
+		t.Fatalf("unexpected suggestions: %v", diagnostics[0].Suggestions)
+	}
+	if got := DiagnoseWithMacros(`@mine and state = "open"`, defs); len(got) != 0 {
+		t.Fatalf("expected no diagnostics, got %+v", got)
+	}
+}
+
+func TestDiagnoseValidFilter(t *testing.T) {
+	if got := Diagnose(`project = "org/repo" and updated in last(7d) order by updated asc`); len(got) != 0 {
+		t.Fatalf("expected no diagnostics, got %+v", got)
+	}
+}
@@ -0,0 +1,261 @@
+package dsl
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/maypok86/otter/v2"
+)
+
+// Subject is implemented by work items a filter can be evaluated against.
+// FilterField returns the item's value for a DSL field as a string, []string,
+// bool, int or time.Time, and false when the item cannot answer for it.
+type Subject interface {
+	FilterField(field string) (any, bool)
+}
+
+// Evaluate reports whether subject matches expr. Values such as "@me" and
+// team(...) must already be expanded.
+func Evaluate(expr Expr, subject Subject, now time.Time) (bool, error) {
+	return evaluate(Normalize(expr), subject, now)
+}
+
+func evaluate(expr Expr, subject Subject, now time.Time) (bool, error) {
+	switch node := expr.(type) {
+	case nil:
+		return true, nil
+	case BinaryExpr:
+		left, err := evaluate(node.Left, subject, now)
+		if err != nil {
+			return false, err
+		}
+		switch node.Op {
+		case OpAnd:
+			if !left {
+				return false, nil
+			}
+		case OpOr:
+			if left {
+				return true, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported boolean operator %q", node.Op)
+		}
+		return evaluate(node.Right, subject, now)
+	case UnaryExpr:
+		matched, err := evaluate(node.Expr, subject, now)
+		if err != nil {
+			return false, err
+		}
+		if node.Negate {
+			return !matched, nil
+		}
+		return matched, nil
+	case PredicateExpr:
+		return evaluatePredicate(node, subject, now)
+	case OrderedExpr:
+		return evaluate(node.Expr, subject, now)
+	default:
+		return false, fmt.Errorf("unsupported expression")
+	}
+}
+
+// CheckFields reports the first predicate in expr that subject cannot answer
+// for, without evaluating anything.
+func CheckFields(expr Expr, subject Subject) error {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		if err := CheckFields(node.Left, subject); err != nil {
+			return err
+		}
+		return CheckFields(node.Right, subject)
+	case UnaryExpr:
+		return CheckFields(node.Expr, subject)
+	case OrderedExpr:
+		return CheckFields(node.Expr, subject)
+	case PredicateExpr:
+		if _, ok := subject.FilterField(strings.ToLower(node.Field)); !ok {
+			return UnsupportedPredicateError{Provider: "local filtering", Field: node.Field, Op: node.Op}
+		}
+	}
+	return nil
+}
+
+func evaluatePredicate(node PredicateExpr, subject Subject, now time.Time) (bool, error) {
+	field := strings.ToLower(node.Field)
+	actual, ok := subject.FilterField(field)
+	if !ok {
+		return false, UnsupportedPredicateError{Provider: "local filtering", Field: node.Field, Op: node.Op}
+	}
+
+	switch op := node.Op.(type) {
+	case CompareOp:
+		return compareField(field, op, actual, node.Value, now)
+	case MembershipOp:
+		if len(node.List) == 0 {
+			return false, fmt.Errorf("empty list for %s", node.Field)
+		}
+		matched := false
+		for _, value := range node.List {
+			eq, err := compareField(field, OpEq, actual, value, now)
+			if err != nil {
+				return false, err
+			}
+			if eq {
+				matched = true
+				break
+			}
+		}
+		if op == OpNotIn {
+			return !matched, nil
+		}
+		return matched, nil
+	default:
+		return false, fmt.Errorf("unsupported operator for %s", node.Field)
+	}
+}
+
+func compareField(field string, op CompareOp, actual any, value Value, now time.Time) (bool, error) {
+	switch actual := actual.(type) {
+	case string:
+		want, err := stringValue(value)
+		if err != nil {
+			return false, err
+		}
+		switch op {
+		case OpMatch:
+			re, err := compilePattern(want)
+			if err != nil {
+				return false, err
+			}
+			return re.MatchString(actual), nil
+		case OpContains:
+			return strings.Contains(strings.ToLower(actual), strings.ToLower(want)), nil
+		}
+		return equalityResult(field, op, matchString(field, actual, want))
+	case []string:
+		want, err := stringValue(value)
+		if err != nil {
+			return false, err
+		}
+		matched := false
+		for _, item := range actual {
+			if matchString(field, item, want) {
+				matched = true
+				break
+			}
+		}
+		return equalityResult(field, op, matched)
+	case bool:
+		want, err := boolValue(value)
+		if err != nil {
+			return false, err
+		}
+		return equalityResult(field, op, actual == want)
+	case int:
+		number, ok := value.(NumberValue)
+		if !ok {
+			return false, fmt.Errorf("expected number value")
+		}
+		return compareOrdered(op, actual-number.Value), nil
+	case time.Time:
+		span, err := resolveTimeSpan(value, now)
+		if err != nil {
+			return false, err
+		}
+		return compareTime(op, actual, span.start, span.end), nil
+	default:
+		return false, fmt.Errorf("cannot evaluate %s locally", field)
+	}
+}
+
+const patternsMaxSize = 1_000
+
+// patterns caches compiled regular expressions, since a residual filter is
+// evaluated once per fetched item. Filters typed into the search bar add a
+// pattern per keystroke, so the cache is bounded.
+var patterns = otter.Must(&otter.Options[string, *regexp.Regexp]{
+	MaximumSize: patternsMaxSize,
+})
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.GetIfPresent(pattern); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	patterns.Set(pattern, re)
+	return re, nil
+}
+
+func matchString(field, actual, want string) bool {
+	switch field {
+	case "text":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(want))
+	case "head", "base":
+		if isGlob(want) {
+			matched, err := path.Match(want, actual)
+			return err == nil && matched
+		}
+		return actual == want
+	default:
+		return strings.EqualFold(actual, want)
+	}
+}
+
+func equalityResult(field string, op CompareOp, eq bool) (bool, error) {
+	switch op {
+	case OpEq:
+		return eq, nil
+	case OpNe:
+		return !eq, nil
+	default:
+		return false, UnsupportedPredicateError{Provider: "local filtering", Field: field, Op: op}
+	}
+}
+
+func compareOrdered(op CompareOp, diff int) bool {
+	switch op {
+	case OpEq:
+		return diff == 0
+	case OpNe:
+		return diff != 0
+	case OpGt:
+		return diff > 0
+	case OpGte:
+		return diff >= 0
+	case OpLt:
+		return diff < 0
+	case OpLte:
+		return diff <= 0
+	default:
+		return false
+	}
+}
+
+// compareTime compares actual against the half-open range [start, end). A
+// date covers a whole day while a duration resolves to a single instant.
+func compareTime(op CompareOp, actual, start, end time.Time) bool {
+	inRange := !actual.Before(start) && actual.Before(end)
+	switch op {
+	case OpEq:
+		return inRange
+	case OpNe:
+		return !inRange
+	case OpGt:
+		return !actual.Before(end)
+	case OpGte:
+		return !actual.Before(start)
+	case OpLt:
+		return actual.Before(start)
+	case OpLte:
+		return actual.Before(end)
+	default:
+		return false
+	}
+}
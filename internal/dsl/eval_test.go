@@ -0,0 +1,85 @@
+package dsl
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeSubject map[string]any
+
+func (s fakeSubject) FilterField(field string) (any, bool) {
+	value, ok := s[field]
+	return value, ok
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	subject := fakeSubject{
+		"state":   "OPEN",
+		"author":  "alice",
+		"label":   []string{"bug", "backend"},
+		"draft":   false,
+		"updated": time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+		"text":    "Fix crash on startup",
+		"title":   "chore(deps): bump lodash",
+		"body":    "Bumps lodash from 4.17.20 to 4.17.21.",
+	}
+	cases := map[string]bool{
+		`state = "open"`:                          true,
+		`author != "alice"`:                       false,
+		`label = "bug" and not label = "wip"`:     true,
+		`label in ["wip", "frontend"]`:            false,
+		`label not in ["wip", "frontend"]`:        true,
+		`draft = true or text = "crash"`:          true,
+		`updated in last(7d)`:                     true,
+		`updated = 2026-03-08`:                    true,
+		`updated > 2026-03-08`:                    false,
+		`updated <= 2026-03-08 and updated < -1d`: true,
+		`title ~ "^chore\\(deps\\)"`:              true,
+		`title ~ "^Chore"`:                        false,
+		`not body contains "LODASH FROM"`:         false,
+		`body contains "react"`:                   false,
+	}
+	for filter, expected := range cases {
+		expr, err := ParseFilter(filter)
+		if err != nil {
+			t.Fatalf("parse %q: %v", filter, err)
+		}
+		matched, err := Evaluate(expr, subject, now)
+		if err != nil {
+			t.Fatalf("evaluate %q: %v", filter, err)
+		}
+		if matched != expected {
+			t.Fatalf("evaluate %q: expected %t, got %t", filter, expected, matched)
+		}
+	}
+}
+
+func TestEvaluateUnknownField(t *testing.T) {
+	expr, err := ParseFilter(`involves = "alice"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, err = Evaluate(expr, fakeSubject{}, time.Now())
+	if err == nil || err.Error() != "local filtering does not support predicate involves" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckFields(t *testing.T) {
+	subject := fakeSubject{"state": "open", "label": []string{"bug"}}
+	expr, err := ParseFilter(`state = "open" and not label = "wip"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := CheckFields(expr, subject); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expr, err = ParseFilter(`state = "open" or involves = "me"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := CheckFields(expr, subject); err == nil || err.Error() != "local filtering does not support predicate involves" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
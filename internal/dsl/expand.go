@@ -1,6 +1,9 @@
 package dsl
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 var userFields = map[string]struct{}{
 	"author":           {},
@@ -17,6 +20,8 @@ func RequiresCurrentUser(expr Expr) bool {
 		return RequiresCurrentUser(node.Expr)
 	case PredicateExpr:
 		return predicateNeedsCurrentUser(node)
+	case OrderedExpr:
+		return RequiresCurrentUser(node.Expr)
 	default:
 		return false
 	}
@@ -37,6 +42,8 @@ func ExpandCurrentUser(expr Expr, username string) Expr {
 		}
 	case PredicateExpr:
 		return expandPredicateCurrentUser(node, username)
+	case OrderedExpr:
+		return OrderedExpr{Expr: ExpandCurrentUser(node.Expr, username), Order: node.Order}
 	default:
 		return expr
 	}
@@ -92,3 +99,105 @@ func isCurrentUserValue(value Value) bool {
 	val := strings.ToLower(str.Value)
 	return val == "me" || val == "@me"
 }
+
+// RequiresTeams reports whether a predicate in expr compares a user field
+// with a team.
+func RequiresTeams(expr Expr) bool {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		return RequiresTeams(node.Left) || RequiresTeams(node.Right)
+	case UnaryExpr:
+		return RequiresTeams(node.Expr)
+	case OrderedExpr:
+		return RequiresTeams(node.Expr)
+	case PredicateExpr:
+		if _, ok := node.Value.(TeamValue); ok {
+			return true
+		}
+		for _, value := range node.List {
+			if _, ok := value.(TeamValue); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ExpandTeams replaces every team(...) in expr with the list of its members
+// as returned by members. Predicates on the fields in keep are left as they
+// are, for providers that can search for a team directly.
+func ExpandTeams(expr Expr, members func(slug string) ([]string, error), keep ...string) (Expr, error) {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		left, err := ExpandTeams(node.Left, members, keep...)
+		if err != nil {
+			return nil, err
+		}
+		right, err := ExpandTeams(node.Right, members, keep...)
+		if err != nil {
+			return nil, err
+		}
+		return BinaryExpr{Op: node.Op, Left: left, Right: right}, nil
+	case UnaryExpr:
+		inner, err := ExpandTeams(node.Expr, members, keep...)
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Negate: node.Negate, Expr: inner}, nil
+	case OrderedExpr:
+		inner, err := ExpandTeams(node.Expr, members, keep...)
+		if err != nil {
+			return nil, err
+		}
+		return OrderedExpr{Expr: inner, Order: node.Order}, nil
+	case PredicateExpr:
+		for _, field := range keep {
+			if strings.EqualFold(node.Field, field) {
+				return node, nil
+			}
+		}
+		return expandPredicateTeams(node, members)
+	default:
+		return expr, nil
+	}
+}
+
+// expandPredicateTeams turns `author = team("acme/backend")` into
+// `author in ["alice", "bob"]`; `!=` and `not in` become `not in`.
+func expandPredicateTeams(node PredicateExpr, members func(slug string) ([]string, error)) (PredicateExpr, error) {
+	values := node.List
+	if team, ok := node.Value.(TeamValue); ok {
+		values = []Value{team}
+		switch node.Op {
+		case OpEq, OpIn:
+			node.Op = OpIn
+		case OpNe, OpNotIn:
+			node.Op = OpNotIn
+		default:
+			return node, fmt.Errorf("operator %q is not supported with %s", fmt.Sprint(node.Op), team.String())
+		}
+		node.Value = nil
+	} else if !RequiresTeams(node) {
+		return node, nil
+	}
+	list := make([]Value, 0, len(values))
+	for _, value := range values {
+		team, ok := value.(TeamValue)
+		if !ok {
+			list = append(list, value)
+			continue
+		}
+		logins, err := members(team.Slug)
+		if err != nil {
+			return node, err
+		}
+		if len(logins) == 0 {
+			return node, fmt.Errorf("team %q has no members", team.Slug)
+		}
+		for _, login := range logins {
+			list = append(list, StringValue{Value: login})
+		}
+	}
+	node.List = list
+	return node, nil
+}
@@ -32,3 +32,47 @@ func TestExpandCurrentUser(t *testing.T) {
 		t.Fatalf("expected search param to remain @me, got %q", query.Params["search"])
 	}
 }
+
+func TestExpandTeams(t *testing.T) {
+	expr, err := ParseFilter(`author in team("acme/backend") and assignee in ["carol", team("acme/ops")] and review_requested = team("acme/backend")`)
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
+	if !RequiresTeams(expr) {
+		t.Fatalf("expected RequiresTeams to be true")
+	}
+	teams := map[string][]string{
+		"acme/backend": {"alice", "bob"},
+		"acme/ops":     {"dave"},
+	}
+	members := func(team string) ([]string, error) {
+		return teams[team], nil
+	}
+	expanded, err := ExpandTeams(expr, members, "review_requested")
+	if err != nil {
+		t.Fatalf("expand teams: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expanded, time.Now())
+	if err != nil {
+		t.Fatalf("translate github: %v", err)
+	}
+	want := "(author:alice OR author:bob) (assignee:carol OR assignee:dave) team-review-requested:acme/backend"
+	if query.Query != want || query.Residual != nil {
+		t.Fatalf("unexpected query: %q, residual %v", query.Query, query.Residual)
+	}
+
+	negated, err := ParseFilter(`author != team("acme/ops")`)
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
+	expanded, err = ExpandTeams(negated, members)
+	if err != nil {
+		t.Fatalf("expand teams: %v", err)
+	}
+	if got := Format(expanded); got != `author not in ["dave"]` {
+		t.Fatalf("unexpected expansion %q", got)
+	}
+	if _, err := ExpandTeams(negated, func(string) ([]string, error) { return nil, nil }); err == nil {
+		t.Fatalf("expected an error for a team without members")
+	}
+}
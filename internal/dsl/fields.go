@@ -0,0 +1,212 @@
+package dsl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// ValueType is the kind of value a field is compared against.
+type ValueType string
+
+const (
+	TypeString ValueType = "string"
+	TypeBool   ValueType = "boolean"
+	TypeNumber ValueType = "number"
+	TypeTime   ValueType = "date"
+)
+
+// FieldSchema describes a field predicates can filter on. Values, when set,
+// lists the only strings the field accepts. Teams is set for the user fields
+// that can be compared with team(...).
+type FieldSchema struct {
+	Name   string
+	Type   ValueType
+	Ops    []string
+	Values []string
+	Teams  bool
+}
+
+var (
+	equalityOps   = []string{"=", "!="}
+	membershipOps = []string{"=", "!=", "in", "not in"}
+	orderedOps    = []string{"=", "!=", ">", ">=", "<", "<=", "in", "not in"}
+	textOps       = []string{"=", "!=", "~", "contains"}
+)
+
+var schema = []FieldSchema{
+	{Name: "archived", Type: TypeBool, Ops: equalityOps},
+	{Name: "assignee", Type: TypeString, Ops: membershipOps, Teams: true},
+	{Name: "author", Type: TypeString, Ops: membershipOps, Teams: true},
+	{Name: "base", Type: TypeString, Ops: membershipOps},
+	{Name: "body", Type: TypeString, Ops: textOps},
+	{Name: "ci", Type: TypeString, Ops: membershipOps, Values: ciStatuses},
+	{Name: "comments", Type: TypeNumber, Ops: orderedOps},
+	{Name: "created", Type: TypeTime, Ops: orderedOps},
+	{Name: "draft", Type: TypeBool, Ops: equalityOps},
+	{Name: "head", Type: TypeString, Ops: membershipOps},
+	{Name: "involves", Type: TypeString, Ops: membershipOps, Teams: true},
+	{Name: "label", Type: TypeString, Ops: membershipOps},
+	{Name: "project", Type: TypeString, Ops: membershipOps},
+	{Name: "provider", Type: TypeString, Ops: membershipOps},
+	{Name: "reactions", Type: TypeNumber, Ops: orderedOps},
+	{Name: "review_requested", Type: TypeString, Ops: membershipOps, Teams: true},
+	{Name: "state", Type: TypeString, Ops: membershipOps},
+	{Name: "text", Type: TypeString, Ops: equalityOps},
+	{Name: "title", Type: TypeString, Ops: textOps},
+	{Name: "type", Type: TypeString, Ops: membershipOps, Values: []string{"pr", "issue"}},
+	{Name: "updated", Type: TypeTime, Ops: orderedOps},
+}
+
+var fieldNames = func() []string {
+	names := make([]string, 0, len(schema))
+	for _, field := range schema {
+		names = append(names, field.Name)
+	}
+	return names
+}()
+
+// FieldNames returns the fields predicates can filter on, sorted.
+func FieldNames() []string {
+	return slices.Clone(fieldNames)
+}
+
+func IsKnownField(name string) bool {
+	_, ok := LookupField(name)
+	return ok
+}
+
+func LookupField(name string) (FieldSchema, bool) {
+	name = strings.ToLower(name)
+	for _, field := range schema {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return FieldSchema{}, false
+}
+
+// checkPredicate reports the first way node does not fit its field's
+// schema. The returned diagnostic has no position.
+func checkPredicate(node PredicateExpr) (Diagnostic, bool) {
+	field, ok := LookupField(node.Field)
+	if !ok {
+		return Diagnostic{}, true
+	}
+	op := fmt.Sprint(node.Op)
+	if !slices.Contains(field.Ops, op) {
+		return Diagnostic{
+			Message:  fmt.Sprintf("operator %q is not allowed for %s", op, field.Name),
+			Expected: field.Ops,
+		}, false
+	}
+	if node.Value != nil {
+		_, team := node.Value.(TeamValue)
+		if _, membership := node.Op.(MembershipOp); membership && field.Type != TypeTime && !team {
+			return Diagnostic{
+				Message:  fmt.Sprintf("%s %s expects a list", field.Name, op),
+				Expected: []string{"["},
+			}, false
+		}
+		if node.Op == OpMatch {
+			if str, ok := node.Value.(StringValue); ok {
+				if _, err := regexp.Compile(str.Value); err != nil {
+					return Diagnostic{Message: fmt.Sprintf("invalid regular expression %s: %v", str.String(), regexpReason(err))}, false
+				}
+			}
+		}
+		return checkValue(field, node.Value)
+	}
+	for _, value := range node.List {
+		if diagnostic, ok := checkValue(field, value); !ok {
+			return diagnostic, false
+		}
+	}
+	return Diagnostic{}, true
+}
+
+func checkValue(field FieldSchema, value Value) (Diagnostic, bool) {
+	mismatch := func(expected ...string) (Diagnostic, bool) {
+		return Diagnostic{
+			Message:  fmt.Sprintf("%s expects %s, got %s", field.Name, typeDescription(field.Type), value.String()),
+			Expected: expected,
+		}, false
+	}
+	if team, ok := value.(TeamValue); ok {
+		if !field.Teams {
+			return Diagnostic{
+				Message: fmt.Sprintf("%s cannot be compared with %s", field.Name, team.String()),
+			}, false
+		}
+		return Diagnostic{}, true
+	}
+	switch field.Type {
+	case TypeString:
+		str, ok := value.(StringValue)
+		if !ok {
+			return mismatch("string")
+		}
+		if len(field.Values) > 0 && !slices.Contains(field.Values, strings.ToLower(str.Value)) {
+			quoted := make([]string, 0, len(field.Values))
+			for _, allowed := range field.Values {
+				quoted = append(quoted, quoteString(allowed))
+			}
+			return Diagnostic{
+				Message:     fmt.Sprintf("%s must be one of %s, got %s", field.Name, strings.Join(field.Values, ", "), value.String()),
+				Expected:    quoted,
+				Suggestions: suggest(quoteString(strings.ToLower(str.Value)), quoted),
+			}, false
+		}
+	case TypeBool:
+		if _, ok := value.(BoolValue); !ok {
+			diagnostic, _ := mismatch("true", "false")
+			if str, ok := value.(StringValue); ok {
+				switch lower := strings.ToLower(str.Value); lower {
+				case "true", "false":
+					diagnostic.Suggestions = []string{lower}
+				default:
+					diagnostic.Suggestions = suggest(lower, []string{"true", "false"})
+				}
+			}
+			return diagnostic, false
+		}
+	case TypeNumber:
+		if _, ok := value.(NumberValue); !ok {
+			return mismatch("number")
+		}
+	case TypeTime:
+		switch value.(type) {
+		case DateValue, DateTimeValue, DurationValue, FunctionValue, CalendarValue, RangeValue:
+		default:
+			return mismatch("date", "duration", "last(...)", "between(...)", "today")
+		}
+	}
+	return Diagnostic{}, true
+}
+
+// regexpReason strips the pattern regexp.Compile repeats in its errors.
+func regexpReason(err error) string {
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		return string(syntaxErr.Code)
+	}
+	return err.Error()
+}
+
+func typeDescription(valueType ValueType) string {
+	switch valueType {
+	case TypeString:
+		return "a quoted string"
+	case TypeBool:
+		return "true or false"
+	case TypeNumber:
+		return "a number"
+	case TypeTime:
+		return "a date, duration or period"
+	default:
+		return string(valueType)
+	}
+}
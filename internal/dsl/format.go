@@ -0,0 +1,205 @@
+package dsl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseFilterUnexpanded parses input without expanding macro references, so
+// the result can be formatted back into what the user wrote.
+func ParseFilterUnexpanded(input string) (Expr, error) {
+	return parseFilter(input)
+}
+
+// FormatFilter parses input and returns its canonical form.
+func FormatFilter(input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", nil
+	}
+	expr, err := parseFilter(input)
+	if err != nil {
+		return "", err
+	}
+	return Format(expr), nil
+}
+
+// Format prints expr as DSL text that parses back into the same expression.
+// Field names and keywords are lowercased and parentheses are only added
+// where precedence requires them.
+func Format(expr Expr) string {
+	var b strings.Builder
+	formatExpr(&b, expr)
+	return b.String()
+}
+
+func formatExpr(b *strings.Builder, expr Expr) {
+	switch node := expr.(type) {
+	case nil:
+	case OrderedExpr:
+		if node.Expr != nil {
+			formatExpr(b, node.Expr)
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(b, "order by %s %s", node.Order.Field, node.Order.Direction)
+	case BinaryExpr:
+		formatOperand(b, node.Left, opPrecedence(node.Op) > exprPrecedence(node.Left))
+		fmt.Fprintf(b, " %s ", node.Op)
+		formatOperand(b, node.Right, opPrecedence(node.Op) >= exprPrecedence(node.Right))
+	case UnaryExpr:
+		if !node.Negate {
+			formatExpr(b, node.Expr)
+			return
+		}
+		b.WriteString("not ")
+		_, binary := node.Expr.(BinaryExpr)
+		formatOperand(b, node.Expr, binary)
+	case MacroExpr:
+		b.WriteString(formatMacro(node.Name))
+	case PredicateExpr:
+		formatPredicate(b, node)
+	}
+}
+
+// FormatTree prints expr as an indented tree, one node per line.
+func FormatTree(expr Expr) string {
+	var b strings.Builder
+	formatTree(&b, expr, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func formatTree(b *strings.Builder, expr Expr, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch node := expr.(type) {
+	case nil:
+		fmt.Fprintf(b, "%s(empty)\n", indent)
+	case OrderedExpr:
+		fmt.Fprintf(b, "%sorder by %s %s\n", indent, node.Order.Field, node.Order.Direction)
+		if node.Expr != nil {
+			formatTree(b, node.Expr, depth+1)
+		}
+	case BinaryExpr:
+		fmt.Fprintf(b, "%s%s\n", indent, node.Op)
+		formatTree(b, node.Left, depth+1)
+		formatTree(b, node.Right, depth+1)
+	case UnaryExpr:
+		if !node.Negate {
+			formatTree(b, node.Expr, depth)
+			return
+		}
+		fmt.Fprintf(b, "%snot\n", indent)
+		formatTree(b, node.Expr, depth+1)
+	default:
+		fmt.Fprintf(b, "%s%s\n", indent, Format(node))
+	}
+}
+
+func formatOperand(b *strings.Builder, expr Expr, parens bool) {
+	if !parens {
+		formatExpr(b, expr)
+		return
+	}
+	b.WriteString("(")
+	formatExpr(b, expr)
+	b.WriteString(")")
+}
+
+func opPrecedence(op BinaryOp) int {
+	if op == OpOr {
+		return 1
+	}
+	return 2
+}
+
+// exprPrecedence ranks how tightly expr binds; anything that is not a boolean
+// operator binds tighter than both.
+func exprPrecedence(expr Expr) int {
+	if node, ok := expr.(BinaryExpr); ok {
+		return opPrecedence(node.Op)
+	}
+	return 3
+}
+
+func formatMacro(name string) string {
+	simple := name != ""
+	for _, ch := range name {
+		if !isMacroNamePart(ch) {
+			simple = false
+			break
+		}
+	}
+	if simple {
+		return "@" + name
+	}
+	return fmt.Sprintf("use(%s)", quoteString(name))
+}
+
+func formatPredicate(b *strings.Builder, node PredicateExpr) {
+	b.WriteString(strings.ToLower(node.Field))
+	switch op := node.Op.(type) {
+	case CompareOp:
+		fmt.Fprintf(b, " %s %s", op, FormatValue(node.Value))
+	case MembershipOp:
+		fmt.Fprintf(b, " %s ", op)
+		if node.Value != nil {
+			b.WriteString(FormatValue(node.Value))
+			return
+		}
+		values := make([]string, 0, len(node.List))
+		for _, value := range node.List {
+			values = append(values, FormatValue(value))
+		}
+		fmt.Fprintf(b, "[%s]", strings.Join(values, ", "))
+	}
+}
+
+// FormatValue prints a single value the way the lexer reads it.
+func FormatValue(value Value) string {
+	switch val := value.(type) {
+	case StringValue:
+		return quoteString(val.Value)
+	case DateTimeValue:
+		return val.Value.Format(time.RFC3339Nano)
+	case DurationValue:
+		return formatDuration(val.Value)
+	case FunctionValue:
+		return fmt.Sprintf("%s(%s)", val.Name, FormatValue(val.Arg))
+	case RangeValue:
+		return fmt.Sprintf("between(%s, %s)", FormatValue(val.From), FormatValue(val.To))
+	case TeamValue:
+		return fmt.Sprintf("team(%s)", quoteString(val.Slug))
+	case nil:
+		return ""
+	default:
+		return val.String()
+	}
+}
+
+func quoteString(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// formatDuration uses the largest unit that represents dur exactly.
+func formatDuration(dur time.Duration) string {
+	sign := ""
+	if dur < 0 {
+		sign = "-"
+		dur = -dur
+	}
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+	for _, unit := range units {
+		if dur%unit.size == 0 {
+			return fmt.Sprintf("%s%d%s", sign, dur/unit.size, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%s%dm", sign, dur/time.Minute)
+}
@@ -0,0 +1,77 @@
+package dsl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatFilter(t *testing.T) {
+	tests := map[string]string{
+		`State="open"   AND author = "me"`:                                  `state = "open" and author = "me"`,
+		`a = "x" or b = "y" and c = "z"`:                                    `a = "x" or b = "y" and c = "z"`,
+		`(a = "x" or b = "y") and c = "z"`:                                  `(a = "x" or b = "y") and c = "z"`,
+		`a = "x" and (b = "y" and c = "z")`:                                 `a = "x" and (b = "y" and c = "z")`,
+		`!(a = "x" or b = "y")`:                                             `not (a = "x" or b = "y")`,
+		`not not draft = true`:                                              `not not draft = true`,
+		`label in ["bug","ui"] and label not in ["wip"]`:                    `label in ["bug", "ui"] and label not in ["wip"]`,
+		`text = "say \"hi\" \\ bye"`:                                        `text = "say \"hi\" \\ bye"`,
+		`updated in last(168h) and created < -14d`:                          `updated in last(1w) and created < -2w`,
+		`updated IN this_week and created in between(2026-01-01,yesterday)`: `updated in this_week and created in between(2026-01-01, yesterday)`,
+		`updated > 2026-01-05T09:30+01:00`:                                  `updated > 2026-01-05T09:30:00+01:00`,
+		`comments >= 3 order by comments`:                                   `comments >= 3 order by comments desc`,
+		`order by created asc`:                                              `order by created asc`,
+		`@mine and use("team reviews")`:                                     `@mine and use("team reviews")`,
+		`use("active")`:                                                     `@active`,
+		``:                                                                  ``,
+	}
+	for input, want := range tests {
+		got, err := FormatFilter(input)
+		if err != nil {
+			t.Fatalf("format error for %q: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: expected %q, got %q", input, want, got)
+		}
+		again, err := FormatFilter(got)
+		if err != nil || again != got {
+			t.Fatalf("%s: formatting is not idempotent: %q, %v", input, again, err)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	for _, input := range []string{
+		`state = "open" and (author = "me" or assignee = "me") and not label in ["wip"]`,
+		`a = "x" and (b = "y" and c = "z") or not (d = "w" or e = "v")`,
+		`provider != "github" and updated in last(36h) order by updated asc`,
+	} {
+		expr, err := ParseFilterUnexpanded(input)
+		if err != nil {
+			t.Fatalf("parse error for %q: %v", input, err)
+		}
+		reparsed, err := ParseFilterUnexpanded(Format(expr))
+		if err != nil {
+			t.Fatalf("parse error for formatted %q: %v", Format(expr), err)
+		}
+		if !reflect.DeepEqual(expr, reparsed) {
+			t.Fatalf("%s: round trip changed the expression to %q", input, Format(expr))
+		}
+	}
+}
+
+func TestFormatTree(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" and not (label = "wip" or draft = true) order by updated`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	want := `order by updated desc
+  and
+    state = "open"
+    not
+      or
+        label = "wip"
+        draft = true`
+	if got := FormatTree(expr); got != want {
+		t.Fatalf("unexpected tree:\n%s", got)
+	}
+}
@@ -0,0 +1,30 @@
+package dsl
+
+import "time"
+
+// UpdatedAfter narrows expr to the items updated after since, keeping its
+// order clause. It returns false when items could start or stop matching
+// expr without being updated: when it constrains created or updated, since
+// relative dates move between refreshes, ci, since checks finishing don't
+// update a pull request, or the members of a team, who can change.
+func UpdatedAfter(expr Expr, since time.Time) (Expr, bool) {
+	for _, field := range []string{"updated", "created", "ci"} {
+		if ReferencesField(expr, field) {
+			return nil, false
+		}
+	}
+	if RequiresTeams(expr) {
+		return nil, false
+	}
+	changed := PredicateExpr{Field: "updated", Op: OpGt, Value: DateTimeValue{Value: since.UTC()}}
+	narrow := func(inner Expr) Expr {
+		if inner == nil {
+			return changed
+		}
+		return BinaryExpr{Op: OpAnd, Left: inner, Right: changed}
+	}
+	if ordered, ok := expr.(OrderedExpr); ok {
+		return OrderedExpr{Expr: narrow(ordered.Expr), Order: ordered.Order}, true
+	}
+	return narrow(expr), true
+}
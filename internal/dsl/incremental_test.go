@@ -0,0 +1,39 @@
+package dsl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpdatedAfter(t *testing.T) {
+	since := time.Date(2026, 3, 4, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: `state = "open"`, want: `state = "open" and updated > 2026-03-04T09:30:00Z`, ok: true},
+		{input: `author = "me" or label = "bug" order by comments desc`, want: `(author = "me" or label = "bug") and updated > 2026-03-04T09:30:00Z order by comments desc`, ok: true},
+		{input: `order by created asc`, want: `updated > 2026-03-04T09:30:00Z order by created asc`, ok: true},
+		{input: `state = "open" and updated > last(7d)`, ok: false},
+		{input: `not created < 2026-01-01`, ok: false},
+		{input: `author = "me" and ci = "failure"`, ok: false},
+		{input: `author in team("acme/backend")`, ok: false},
+	}
+	for _, tt := range tests {
+		expr, err := ParseFilter(tt.input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.input, err)
+		}
+		narrowed, ok := UpdatedAfter(expr, since)
+		if ok != tt.ok {
+			t.Fatalf("%s: expected ok %v, got %v", tt.input, tt.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if got := Format(narrowed); got != tt.want {
+			t.Fatalf("%s: expected %q, got %q", tt.input, tt.want, got)
+		}
+	}
+}
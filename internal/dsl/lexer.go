@@ -16,6 +16,12 @@ func newLexer(input string) *lexer {
 }
 
 func (l *lexer) nextToken() (token, error) {
+	tok, err := l.scan()
+	tok.end = l.pos
+	return tok, err
+}
+
+func (l *lexer) scan() (token, error) {
 	l.skipWhitespace()
 	if l.pos >= len(l.input) {
 		return token{typ: tokenEOF, pos: l.pos}, nil
@@ -40,8 +46,10 @@ func (l *lexer) nextToken() (token, error) {
 		return token{typ: tokenComma, lit: ",", pos: l.pos - 1}, nil
 	case '"':
 		return l.readString()
-	case '!', '<', '>', '=':
+	case '!', '<', '>', '=', '~':
 		return l.readOperator()
+	case '@':
+		return l.readMacro()
 	}
 
 	if isIdentStart(ch) {
@@ -52,7 +60,16 @@ func (l *lexer) nextToken() (token, error) {
 		return l.readNumberDateOrDuration()
 	}
 
-	return token{}, fmt.Errorf("unexpected character %q at %d", ch, l.pos)
+	diagnostic := l.diagnostic(l.pos, l.pos+1, fmt.Sprintf("unexpected character %q", ch))
+	switch ch {
+	case '&':
+		diagnostic = diagnostic.suggesting("and")
+	case '|':
+		diagnostic = diagnostic.suggesting("or")
+	case '\'':
+		diagnostic = diagnostic.suggesting(`"`)
+	}
+	return token{}, diagnostic
 }
 
 func (l *lexer) skipWhitespace() {
@@ -89,7 +106,7 @@ func (l *lexer) readString() (token, error) {
 			b.WriteRune(ch)
 		}
 	}
-	return token{}, fmt.Errorf("unterminated string starting at %d", start)
+	return token{}, l.diagnostic(start, l.pos, "unterminated string").expecting(`"`)
 }
 
 func (l *lexer) readOperator() (token, error) {
@@ -106,6 +123,19 @@ func (l *lexer) readOperator() (token, error) {
 	return token{typ: tokenOp, lit: string(ch), pos: start}, nil
 }
 
+func (l *lexer) readMacro() (token, error) {
+	start := l.pos
+	l.pos++ // consume @
+	nameStart := l.pos
+	for l.pos < len(l.input) && isMacroNamePart(l.input[l.pos]) {
+		l.pos++
+	}
+	if nameStart == l.pos {
+		return token{}, l.diagnostic(start, l.pos, "expected macro name after '@'")
+	}
+	return token{typ: tokenMacro, lit: string(l.input[nameStart:l.pos]), pos: start}, nil
+}
+
 func (l *lexer) readIdent() (token, error) {
 	start := l.pos
 	for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
@@ -140,7 +170,7 @@ func (l *lexer) readNumberDateOrDuration() (token, error) {
 		l.pos++
 	}
 	if digitsStart == l.pos {
-		return token{}, fmt.Errorf("expected digits at %d", l.pos)
+		return token{}, l.diagnostic(start, l.pos, "expected digits")
 	}
 
 	if sign == 1 && l.pos < len(l.input) && l.input[l.pos] == '-' {
@@ -167,10 +197,36 @@ func (l *lexer) readDate(start int) (token, error) {
 	for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '-') {
 		l.pos++
 	}
+	if l.pos+1 < len(l.input) && (l.input[l.pos] == 'T' || l.input[l.pos] == 't') && unicode.IsDigit(l.input[l.pos+1]) {
+		l.readTime()
+	}
 	lit := string(l.input[start:l.pos])
 	return token{typ: tokenDate, lit: lit, pos: start}, nil
 }
 
+// readTime consumes the time part of a date-time, including an optional Z or
+// +hh:mm offset.
+func (l *lexer) readTime() {
+	l.pos++ // consume T
+	for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == ':' || l.input[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return
+	}
+	switch l.input[l.pos] {
+	case 'Z', 'z':
+		l.pos++
+	case '+', '-':
+		if l.pos+1 < len(l.input) && unicode.IsDigit(l.input[l.pos+1]) {
+			l.pos++
+			for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == ':') {
+				l.pos++
+			}
+		}
+	}
+}
+
 func isIdentStart(ch rune) bool {
 	return ch == '_' || unicode.IsLetter(ch)
 }
@@ -178,3 +234,17 @@ func isIdentStart(ch rune) bool {
 func isIdentPart(ch rune) bool {
 	return isIdentStart(ch) || unicode.IsDigit(ch)
 }
+
+func isMacroNamePart(ch rune) bool {
+	return isIdentPart(ch) || ch == '-'
+}
+
+func (l *lexer) diagnostic(start, end int, message string) Diagnostic {
+	return Diagnostic{Start: l.offset(start), End: l.offset(end), Message: message}
+}
+
+// offset converts a rune position into a byte offset.
+func (l *lexer) offset(pos int) int {
+	pos = min(max(pos, 0), len(l.input))
+	return len(string(l.input[:pos]))
+}
@@ -0,0 +1,100 @@
+package dsl
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type MacroError struct {
+	Name string
+	Err  error
+}
+
+func (err MacroError) Error() string {
+	return fmt.Sprintf("filter macro %q: %v", err.Name, err.Err)
+}
+
+func (err MacroError) Unwrap() error {
+	return err.Err
+}
+
+// ExpandMacros replaces every macro reference in expr with its parsed
+// definition.
+func ExpandMacros(expr Expr, defs map[string]string) (Expr, error) {
+	return expandMacros(expr, defs, nil)
+}
+
+// CheckMacros parses every definition and reports the first macro, by name,
+// that is invalid, references an undefined macro or is part of a cycle.
+func CheckMacros(defs map[string]string) error {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := expandMacros(MacroExpr{Name: name}, defs, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandMacros(expr Expr, defs map[string]string, stack []string) (Expr, error) {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		left, err := expandMacros(node.Left, defs, stack)
+		if err != nil {
+			return nil, err
+		}
+		right, err := expandMacros(node.Right, defs, stack)
+		if err != nil {
+			return nil, err
+		}
+		return BinaryExpr{Op: node.Op, Left: left, Right: right}, nil
+	case UnaryExpr:
+		inner, err := expandMacros(node.Expr, defs, stack)
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Negate: node.Negate, Expr: inner}, nil
+	case OrderedExpr:
+		inner, err := expandMacros(node.Expr, defs, stack)
+		if err != nil {
+			return nil, err
+		}
+		return OrderedExpr{Expr: inner, Order: node.Order}, nil
+	case MacroExpr:
+		return expandMacro(node.Name, defs, stack)
+	default:
+		return expr, nil
+	}
+}
+
+func expandMacro(name string, defs map[string]string, stack []string) (Expr, error) {
+	if slices.Contains(stack, name) {
+		cycle := append(slices.Clone(stack), name)
+		return nil, MacroError{Name: name, Err: fmt.Errorf("cycle %s", strings.Join(cycle, " -> "))}
+	}
+	def, ok := defs[name]
+	if !ok {
+		return nil, MacroError{Name: name, Err: fmt.Errorf("not defined")}
+	}
+	parsed, err := parseFilter(def)
+	if err != nil {
+		return nil, MacroError{Name: name, Err: err}
+	}
+	if _, ok := parsed.(OrderedExpr); ok {
+		return nil, MacroError{Name: name, Err: fmt.Errorf("macros cannot contain an order clause")}
+	}
+	expanded, err := expandMacros(parsed, defs, append(stack, name))
+	if err != nil {
+		if _, ok := err.(MacroError); ok {
+			return nil, err
+		}
+		return nil, MacroError{Name: name, Err: err}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,78 @@
+package dsl
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFilterWithMacros(t *testing.T) {
+	defs := map[string]string{
+		"mine":   `author = "me" or assignee = "me"`,
+		"active": `state = "open" and @mine`,
+	}
+	expr, err := ParseFilterWithMacros(`use("active") and draft = false`, defs)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	want, err := parseFilter(`(state = "open" and (author = "me" or assignee = "me")) and draft = false`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if !reflect.DeepEqual(expr, want) {
+		t.Fatalf("unexpected expansion: %#v", expr)
+	}
+}
+
+func TestParseFilterWithMacrosErrors(t *testing.T) {
+	defs := map[string]string{
+		"a":       `@b`,
+		"b":       `state = "open" and @a`,
+		"broken":  `state = `,
+		"ordered": `state = "open" order by updated`,
+	}
+	tests := []struct {
+		filter string
+		name   string
+		reason string
+	}{
+		{filter: `@a`, name: "a", reason: "cycle a -> b -> a"},
+		{filter: `@missing`, name: "missing", reason: "not defined"},
+		{filter: `use("broken")`, name: "broken", reason: "expected value"},
+		{filter: `@ordered`, name: "ordered", reason: "order clause"},
+	}
+	for _, tt := range tests {
+		_, err := ParseFilterWithMacros(tt.filter, defs)
+		var macroErr MacroError
+		if !errors.As(err, &macroErr) {
+			t.Fatalf("%s: expected macro error, got %v", tt.filter, err)
+		}
+		if macroErr.Name != tt.name || !strings.Contains(err.Error(), tt.reason) {
+			t.Fatalf("%s: unexpected error %v", tt.filter, err)
+		}
+	}
+}
+
+func TestCheckMacros(t *testing.T) {
+	if err := CheckMacros(map[string]string{"open": `state = "open"`, "mine": `@open and author = "me"`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := CheckMacros(map[string]string{"self": `@self`})
+	if err == nil || !strings.Contains(err.Error(), `filter macro "self": cycle self -> self`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseFilterExpandsOnlyGivenMacros(t *testing.T) {
+	expr, err := ParseFilterWithMacros(`@open`, map[string]string{"open": `state = "open"`})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if pred, ok := expr.(PredicateExpr); !ok || pred.Field != "state" {
+		t.Fatalf("unexpected expansion: %#v", expr)
+	}
+	if _, err := ParseFilter(`@open`); err == nil {
+		t.Fatalf("expected ParseFilter to report the macro as undefined")
+	}
+}
@@ -0,0 +1,292 @@
+package dsl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LegacyMigration is the DSL equivalent of a legacy GitHub search filter.
+// Skipped holds one error per qualifier that has no equivalent; those
+// qualifiers are left out of Filter.
+type LegacyMigration struct {
+	Filter  string
+	Skipped []ValidationError
+}
+
+var legacyUserQualifiers = map[string]string{
+	"author":           "author",
+	"assignee":         "assignee",
+	"involves":         "involves",
+	"review-requested": "review_requested",
+}
+
+var legacyQualifierHints = map[string]string{
+	"org":     `use project = "org/repo" for each repository`,
+	"user":    `use project = "owner/repo" for each repository`,
+	"in":      `use title contains "..." or body contains "..." instead`,
+	"updated": `use a date, a duration such as -3w, or between(...)`,
+	"created": `use a date, a duration such as -3w, or between(...)`,
+}
+
+var nowModifyTemplate = regexp.MustCompile(`^\{\{\s*nowModify\s+"(-?\d+[mhdw])"\s*\}\}$`)
+
+// MigrateLegacy translates a filter written with GitHub search qualifiers,
+// such as `is:open author:@me`, into the DSL.
+func MigrateLegacy(filter string) LegacyMigration {
+	var migration LegacyMigration
+	var parts []string
+	var repos []string
+	reposAt := -1
+	order := ""
+
+	skip := func(token, hint string) {
+		if hint == "" {
+			hint = "rewrite it by hand using the DSL"
+		}
+		migration.Skipped = append(migration.Skipped, ValidationError{
+			Reason: fmt.Sprintf("legacy qualifier %q has no DSL equivalent", token),
+			Hint:   hint,
+		})
+	}
+
+	for _, token := range splitLegacyTokens(filter) {
+		negate := strings.HasPrefix(token, "-") && len(token) > 1
+		qualifier := strings.TrimPrefix(token, "-")
+		key, value, ok := strings.Cut(qualifier, ":")
+		if !ok {
+			switch {
+			case strings.EqualFold(token, "OR") || strings.EqualFold(token, "AND") || strings.EqualFold(token, "NOT") ||
+				strings.ContainsAny(token, "()"):
+				skip(token, "combine predicates with and, or and not by hand")
+			case negate:
+				skip(token, "the DSL cannot exclude words")
+			default:
+				parts = append(parts, fmt.Sprintf("text = %s", quoteString(unquoteLegacy(token))))
+			}
+			continue
+		}
+		key = strings.ToLower(key)
+		value = unquoteLegacy(value)
+
+		if key == "repo" && !negate {
+			if reposAt == -1 {
+				reposAt = len(parts)
+				parts = append(parts, "")
+			}
+			repos = append(repos, value)
+			continue
+		}
+		if key == "sort" && !negate {
+			clause, ok := legacySortClause(value)
+			if !ok {
+				skip(token, "order by updated, created, comments or reactions")
+				continue
+			}
+			order = clause
+			continue
+		}
+
+		part, ok := legacyQualifierToDSL(key, value, negate)
+		if !ok {
+			skip(token, legacyQualifierHints[key])
+			continue
+		}
+		if _, err := parseFilter(part); err != nil {
+			skip(token, fmt.Sprintf("its value is not valid in the DSL: %v", err))
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	switch len(repos) {
+	case 0:
+	case 1:
+		parts[reposAt] = fmt.Sprintf("project = %s", quoteString(repos[0]))
+	default:
+		quoted := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			quoted = append(quoted, quoteString(repo))
+		}
+		parts[reposAt] = fmt.Sprintf("project in [%s]", strings.Join(quoted, ", "))
+	}
+
+	migrated := strings.Join(parts, " and ")
+	if order != "" {
+		migrated = strings.TrimSpace(migrated + " " + order)
+	}
+	if formatted, err := FormatFilter(migrated); err == nil {
+		migrated = formatted
+	}
+	migration.Filter = migrated
+	return migration
+}
+
+func legacyQualifierToDSL(key, value string, negate bool) (string, bool) {
+	eq := "="
+	if negate {
+		eq = "!="
+	}
+	switch key {
+	case "is", "state":
+		switch strings.ToLower(value) {
+		case "open", "closed", "merged":
+			return fmt.Sprintf("state %s %s", eq, quoteString(strings.ToLower(value))), true
+		case "pr", "issue":
+			if negate {
+				return "", false
+			}
+			return fmt.Sprintf("type = %s", quoteString(strings.ToLower(value))), true
+		case "draft", "archived":
+			return fmt.Sprintf("%s = %t", strings.ToLower(value), !negate), true
+		}
+		return "", false
+	case "draft", "archived":
+		switch strings.ToLower(value) {
+		case "true", "false":
+			return fmt.Sprintf("%s %s %s", key, eq, strings.ToLower(value)), true
+		}
+		return "", false
+	case "author", "assignee", "involves", "review-requested":
+		user := strings.TrimPrefix(value, "@")
+		if strings.EqualFold(user, "me") {
+			user = "me"
+		}
+		return fmt.Sprintf("%s %s %s", legacyUserQualifiers[key], eq, quoteString(user)), true
+	case "team-review-requested":
+		return fmt.Sprintf("review_requested %s team(%s)", eq, quoteString(value)), true
+	case "repo", "label", "head", "base", "status":
+		field := map[string]string{
+			"repo":   "project",
+			"label":  "label",
+			"head":   "head",
+			"base":   "base",
+			"status": "ci",
+		}[key]
+		values := strings.Split(value, ",")
+		if len(values) == 1 {
+			return fmt.Sprintf("%s %s %s", field, eq, quoteString(value)), true
+		}
+		quoted := make([]string, 0, len(values))
+		for _, val := range values {
+			quoted = append(quoted, quoteString(val))
+		}
+		op := "in"
+		if negate {
+			op = "not in"
+		}
+		return fmt.Sprintf("%s %s [%s]", field, op, strings.Join(quoted, ", ")), true
+	case "updated", "created":
+		if negate {
+			return "", false
+		}
+		return legacyDateToDSL(key, value)
+	default:
+		return "", false
+	}
+}
+
+func legacyDateToDSL(field, value string) (string, bool) {
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		switch {
+		case from == "*":
+			return legacyDateToDSL(field, "<="+to)
+		case to == "*":
+			return legacyDateToDSL(field, ">="+from)
+		}
+		fromValue, ok := legacyDateValue(from)
+		if !ok {
+			return "", false
+		}
+		toValue, ok := legacyDateValue(to)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s in between(%s, %s)", field, fromValue, toValue), true
+	}
+
+	op := "="
+	for _, candidate := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			value = strings.TrimPrefix(value, candidate)
+			break
+		}
+	}
+	date, ok := legacyDateValue(value)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s %s", field, op, date), true
+}
+
+// legacyDateValue accepts dates and date-times as they are and the
+// nowModify template helper with a whole number of units.
+func legacyDateValue(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if match := nowModifyTemplate.FindStringSubmatch(value); match != nil {
+		return match[1], true
+	}
+	if value == "" || strings.Contains(value, "{{") {
+		return "", false
+	}
+	return value, true
+}
+
+func legacySortClause(value string) (string, bool) {
+	field, direction, _ := strings.Cut(strings.ToLower(value), "-")
+	switch SortField(field) {
+	case SortUpdated, SortCreated, SortComments, SortReactions:
+	default:
+		return "", false
+	}
+	switch SortDirection(direction) {
+	case "":
+		direction = string(SortDesc)
+	case SortAsc, SortDesc:
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("order by %s %s", field, direction), true
+}
+
+// splitLegacyTokens splits a GitHub search string on whitespace outside of
+// quotes and template actions.
+func splitLegacyTokens(filter string) []string {
+	var tokens []string
+	var b strings.Builder
+	inQuote := false
+	inTemplate := false
+	for i := 0; i < len(filter); i++ {
+		ch := filter[i]
+		switch {
+		case !inQuote && strings.HasPrefix(filter[i:], "{{"):
+			inTemplate = true
+		case inTemplate && strings.HasPrefix(filter[i:], "}}"):
+			inTemplate = false
+			b.WriteString("}}")
+			i++
+			continue
+		case ch == '"' && !inTemplate:
+			inQuote = !inQuote
+		case isWhitespace(rune(ch)) && !inQuote && !inTemplate:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens
+}
+
+func unquoteLegacy(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,50 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrateLegacy(t *testing.T) {
+	tests := map[string]string{
+		`is:open author:@me`:                                  `state = "open" and author = "me"`,
+		`is:open involves:@me -author:@me`:                    `state = "open" and involves = "me" and author != "me"`,
+		`repo:dlvhdr/gh-dash repo:dlvhdr/x is:pr`:             `project in ["dlvhdr/gh-dash", "dlvhdr/x"] and type = "pr"`,
+		`is:open review-requested:@me -is:draft`:              `state = "open" and review_requested = "me" and draft = false`,
+		`label:bug,critical -label:wip label:"needs ui"`:      `label in ["bug", "critical"] and label != "wip" and label = "needs ui"`,
+		`base:main -head:dependabot/npm status:failure`:       `base = "main" and head != "dependabot/npm" and ci = "failure"`,
+		`updated:>=2025-12-01 created:2025-01-01..2025-01-31`: `updated >= 2025-12-01 and created in between(2025-01-01, 2025-01-31)`,
+		`updated:>={{ nowModify "-3w" }} sort:updated-asc`:    `updated >= -3w order by updated asc`,
+		`created:*..2025-01-31 sort:reactions`:                `created <= 2025-01-31 order by reactions desc`,
+		`dependency "major upgrade" archived:false`:           `text = "dependency" and text = "major upgrade" and archived = false`,
+		`is:open team-review-requested:acme/backend`:          `state = "open" and review_requested = team("acme/backend")`,
+	}
+	for input, want := range tests {
+		migration := MigrateLegacy(input)
+		if len(migration.Skipped) > 0 {
+			t.Fatalf("%s: unexpected skipped qualifiers: %v", input, migration.Skipped)
+		}
+		if migration.Filter != want {
+			t.Fatalf("%s: expected %q, got %q", input, want, migration.Filter)
+		}
+		if err := ValidateFilter(migration.Filter, nil); err != nil {
+			t.Fatalf("%s: migrated filter is invalid: %v", input, err)
+		}
+	}
+}
+
+func TestMigrateLegacyReportsUntranslatedQualifiers(t *testing.T) {
+	migration := MigrateLegacy(`is:open org:dlvhdr updated:>={{ nowModify "-2.5w" }} sort:interactions`)
+	if migration.Filter != `state = "open"` {
+		t.Fatalf("unexpected filter: %q", migration.Filter)
+	}
+	if len(migration.Skipped) != 3 {
+		t.Fatalf("expected 3 skipped qualifiers, got %v", migration.Skipped)
+	}
+	if !strings.Contains(migration.Skipped[0].Error(), `legacy qualifier "org:dlvhdr" has no DSL equivalent (use project = "org/repo"`) {
+		t.Fatalf("unexpected error: %v", migration.Skipped[0])
+	}
+	if !strings.Contains(migration.Skipped[1].Error(), `(use a date, a duration such as -3w, or between(...))`) {
+		t.Fatalf("unexpected error: %v", migration.Skipped[1])
+	}
+}
@@ -17,6 +17,8 @@ func Normalize(expr Expr) Expr {
 		}
 	case PredicateExpr:
 		return normalizePredicate(node)
+	case OrderedExpr:
+		return OrderedExpr{Expr: Normalize(node.Expr), Order: node.Order}
 	default:
 		return expr
 	}
@@ -35,10 +37,23 @@ func normalizePredicate(node PredicateExpr) PredicateExpr {
 	node.Value = normalizeValue(node.Value)
 	if fn, ok := node.Value.(FunctionValue); ok && fn.Name == "last" {
 		if dur, ok := fn.Arg.(DurationValue); ok {
-			node.Op = OpGte
+			if node.Op == OpNotIn {
+				node.Op = OpLt
+			} else {
+				node.Op = OpGte
+			}
 			node.Value = DurationValue{Value: -dur.Value}
 		}
 	}
+	switch node.Value.(type) {
+	case CalendarValue, RangeValue, TeamValue:
+		switch node.Op {
+		case OpIn:
+			node.Op = OpEq
+		case OpNotIn:
+			node.Op = OpNe
+		}
+	}
 	return node
 }
 
@@ -0,0 +1,115 @@
+package dsl
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SplitOrder separates the filter from its `order by` clause. The returned
+// order is nil when the filter has none.
+func SplitOrder(expr Expr) (Expr, *OrderBy) {
+	if ordered, ok := expr.(OrderedExpr); ok {
+		order := ordered.Order
+		return ordered.Expr, &order
+	}
+	return expr, nil
+}
+
+func (order OrderBy) String() string {
+	return fmt.Sprintf("order by %s %s", order.Field, order.Direction)
+}
+
+func orderToGitHub(order OrderBy) string {
+	return fmt.Sprintf("sort:%s-%s", order.Field, order.Direction)
+}
+
+// orderToGitLab sets order_by and sort when GitLab can sort by the field
+// for every resource. The rest are sorted locally.
+func orderToGitLab(order OrderBy, params map[string]string) {
+	switch order.Field {
+	case SortUpdated, SortCreated:
+		params["order_by"] = fmt.Sprintf("%s_at", order.Field)
+		params["sort"] = string(order.Direction)
+	}
+}
+
+// SortSubjects sorts items in place by the order's field. Items that cannot
+// report the field compare as equal and keep their relative position.
+func SortSubjects[T Subject](items []T, order OrderBy) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		return CompareInOrder(a, b, order)
+	})
+}
+
+// CompareInOrder compares two items by where order puts them.
+func CompareInOrder[T Subject](a, b T, order OrderBy) int {
+	cmp := CompareSubjects(a, b, order.Field)
+	if order.Direction == SortDesc {
+		return -cmp
+	}
+	return cmp
+}
+
+// DefaultOrder is how results of several providers are merged when the
+// filter has no `order by` clause.
+var DefaultOrder = OrderBy{Field: SortUpdated, Direction: SortDesc}
+
+// MergeSubjects merges lists, each sorted by order, into one sorted list.
+// Equal items keep the order of their lists, so the result doesn't depend
+// on which list was filled first. Lists that aren't sorted are sorted first.
+func MergeSubjects[T Subject](lists [][]T, order OrderBy) []T {
+	compare := func(a, b T) int {
+		return CompareInOrder(a, b, order)
+	}
+	total := 0
+	for i, list := range lists {
+		if !slices.IsSortedFunc(list, compare) {
+			lists[i] = slices.Clone(list)
+			slices.SortStableFunc(lists[i], compare)
+		}
+		total += len(list)
+	}
+
+	merged := make([]T, 0, total)
+	heads := make([]int, len(lists))
+	for len(merged) < total {
+		next := -1
+		for i, list := range lists {
+			if heads[i] == len(list) {
+				continue
+			}
+			if next == -1 || compare(list[heads[i]], lists[next][heads[next]]) < 0 {
+				next = i
+			}
+		}
+		merged = append(merged, lists[next][heads[next]])
+		heads[next]++
+	}
+	return merged
+}
+
+// CompareSubjects compares two items by a sort field in ascending order.
+func CompareSubjects(a, b Subject, field SortField) int {
+	left, leftOk := a.FilterField(string(field))
+	right, rightOk := b.FilterField(string(field))
+	if !leftOk || !rightOk {
+		return 0
+	}
+	switch left := left.(type) {
+	case time.Time:
+		right, ok := right.(time.Time)
+		if !ok {
+			return 0
+		}
+		return left.Compare(right)
+	case int:
+		right, ok := right.(int)
+		if !ok {
+			return 0
+		}
+		return left - right
+	default:
+		return 0
+	}
+}
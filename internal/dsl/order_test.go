@@ -0,0 +1,109 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFilterOrderClause(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" order by comments asc`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	inner, order := SplitOrder(expr)
+	if order == nil || order.Field != SortComments || order.Direction != SortAsc {
+		t.Fatalf("unexpected order: %#v", order)
+	}
+	if _, ok := inner.(PredicateExpr); !ok {
+		t.Fatalf("expected predicate, got %#v", inner)
+	}
+
+	expr, err = ParseFilter(`order by updated`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	inner, order = SplitOrder(expr)
+	if inner != nil || order == nil || order.Direction != SortDesc {
+		t.Fatalf("unexpected order-only filter: %#v %#v", inner, order)
+	}
+}
+
+func TestParseFilterOrderClauseErrors(t *testing.T) {
+	for _, filter := range []string{
+		`state = "open" order updated`,
+		`state = "open" order by title`,
+		`state = "open" order by updated sideways`,
+		`state = "open" order by updated desc and draft = true`,
+	} {
+		if _, err := ParseFilter(filter); err == nil {
+			t.Fatalf("expected error for %q", filter)
+		}
+	}
+}
+
+func TestTranslateOrder(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" order by created asc`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	github, err := TranslateGitHub(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if github.Query != "is:open sort:created-asc" {
+		t.Fatalf("unexpected query: %q", github.Query)
+	}
+	gitlab, err := TranslateGitLab(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if gitlab.Params["order_by"] != "created_at" || gitlab.Params["sort"] != "asc" {
+		t.Fatalf("unexpected params: %#v", gitlab.Params)
+	}
+
+	expr, err = ParseFilter(`state = "open" order by reactions`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	gitlab, err = TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if _, ok := gitlab.Params["order_by"]; ok || gitlab.Order == nil {
+		t.Fatalf("expected reactions to be sorted locally: %#v", gitlab)
+	}
+}
+
+func TestSortSubjects(t *testing.T) {
+	items := []fakeSubject{
+		{"comments": 1},
+		{"comments": 5},
+		{"comments": 3},
+	}
+	SortSubjects(items, OrderBy{Field: SortComments, Direction: SortDesc})
+	for i, expected := range []int{5, 3, 1} {
+		if items[i]["comments"] != expected {
+			t.Fatalf("unexpected order: %#v", items)
+		}
+	}
+}
+
+func TestMergeSubjects(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2026, 5, 1, hour, 0, 0, 0, time.UTC)
+	}
+	lists := [][]fakeSubject{
+		{{"updated": at(9), "id": "a9"}, {"updated": at(5), "id": "a5"}, {"updated": at(1), "id": "a1"}},
+		{{"updated": at(5), "id": "b5"}, {"updated": at(8), "id": "b8"}},
+		nil,
+	}
+	merged := MergeSubjects(lists, DefaultOrder)
+	var ids []string
+	for _, item := range merged {
+		ids = append(ids, item["id"].(string))
+	}
+	if got := strings.Join(ids, " "); got != "a9 b8 a5 b5 a1" {
+		t.Fatalf("unexpected order: %s", got)
+	}
+}
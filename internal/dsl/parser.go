@@ -11,16 +11,69 @@ type parser struct {
 	lexer  *lexer
 	curr   token
 	peeked bool
+
+	// fields and macros record the tokens naming predicate fields and macro
+	// references, so Diagnose can check them after parsing.
+	fields     []token
+	macros     []token
+	predicates []predicateSpan
+	// end is the end of the last token consumed.
+	end int
+
+	// err is the first lexer error. Lookahead ignores errors, so it takes
+	// precedence over whatever the parser reports after it.
+	err error
 }
 
+// ParseFilter parses input, which must not reference macros.
 func ParseFilter(input string) (Expr, error) {
-	p := &parser{lexer: newLexer(input)}
-	expr, err := p.parseExpr()
+	return ParseFilterWithMacros(input, nil)
+}
+
+// ParseFilterWithMacros parses input and expands references to defs, the
+// named filters of the config's top-level `filters` map.
+func ParseFilterWithMacros(input string, defs map[string]string) (Expr, error) {
+	expr, err := parseFilter(input)
 	if err != nil {
 		return nil, err
 	}
+	return ExpandMacros(expr, defs)
+}
+
+func parseFilter(input string) (Expr, error) {
+	return newParser(input).parseFilter()
+}
+
+func newParser(input string) *parser {
+	return &parser{lexer: newLexer(input)}
+}
+
+func (p *parser) parseFilter() (Expr, error) {
+	expr, err := p.parseAll()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return expr, err
+}
+
+func (p *parser) parseAll() (Expr, error) {
+	var expr Expr
+	if !p.atOrderClause() {
+		var err error
+		expr, err = p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.atOrderClause() {
+		order, err := p.parseOrderClause()
+		if err != nil {
+			return nil, err
+		}
+		expr = OrderedExpr{Expr: expr, Order: order}
+	}
 	if tok, _ := p.next(); tok.typ != tokenEOF {
-		return nil, fmt.Errorf("unexpected token %q at %d", tok.lit, tok.pos)
+		return nil, p.errorAt(tok, "unexpected token %q", tok.lit).expecting("and", "or", "order by")
 	}
 	return expr, nil
 }
@@ -28,13 +81,18 @@ func ParseFilter(input string) (Expr, error) {
 func (p *parser) next() (token, error) {
 	if p.peeked {
 		p.peeked = false
+		p.end = p.curr.end
 		return p.curr, nil
 	}
 	tok, err := p.lexer.nextToken()
 	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
 		return token{}, err
 	}
 	p.curr = tok
+	p.end = tok.end
 	return tok, nil
 }
 
@@ -44,6 +102,9 @@ func (p *parser) peek() (token, error) {
 	}
 	tok, err := p.lexer.nextToken()
 	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
 		return token{}, err
 	}
 	p.curr = tok
@@ -128,11 +189,50 @@ func (p *parser) parsePrimary() (Expr, error) {
 			return nil, err
 		}
 		if tok, _ := p.next(); tok.typ != tokenRParen {
-			return nil, fmt.Errorf("expected ')' at %d", tok.pos)
+			return nil, p.errorAt(tok, "expected ')'").expecting(")")
 		}
 		return expr, nil
 	}
-	return p.parsePredicate()
+	if tok.typ == tokenMacro {
+		_, _ = p.next()
+		p.macros = append(p.macros, tok)
+		return MacroExpr{Name: tok.lit}, nil
+	}
+	if tok.typ == tokenIdent && strings.ToLower(tok.lit) == "use" {
+		return p.parseUse()
+	}
+	expr, err := p.parsePredicate()
+	if err != nil {
+		return nil, err
+	}
+	p.predicates = append(p.predicates, predicateSpan{pos: tok.pos, end: p.end, expr: expr.(PredicateExpr)})
+	return expr, nil
+}
+
+// predicateSpan is a parsed predicate with the rune range it was read from.
+type predicateSpan struct {
+	pos  int
+	end  int
+	expr PredicateExpr
+}
+
+func (p *parser) parseUse() (Expr, error) {
+	useTok, _ := p.next()
+	if tok, _ := p.next(); tok.typ != tokenLParen {
+		return nil, p.errorAt(tok, "expected '(' after %q", useTok.lit)
+	}
+	nameTok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if nameTok.typ != tokenString {
+		return nil, p.errorAt(nameTok, "expected quoted macro name")
+	}
+	if tok, _ := p.next(); tok.typ != tokenRParen {
+		return nil, p.errorAt(tok, "expected ')' after macro name").expecting(")")
+	}
+	p.macros = append(p.macros, nameTok)
+	return MacroExpr{Name: nameTok.lit}, nil
 }
 
 func (p *parser) parsePredicate() (Expr, error) {
@@ -141,8 +241,9 @@ func (p *parser) parsePredicate() (Expr, error) {
 		return nil, err
 	}
 	if fieldTok.typ != tokenIdent {
-		return nil, fmt.Errorf("expected field identifier at %d", fieldTok.pos)
+		return nil, p.errorAt(fieldTok, "expected field identifier").expecting("field name")
 	}
+	p.fields = append(p.fields, fieldTok)
 
 	opTok, err := p.next()
 	if err != nil {
@@ -154,19 +255,28 @@ func (p *parser) parsePredicate() (Expr, error) {
 			return nil, err
 		}
 		if inTok.typ != tokenIn {
-			return nil, fmt.Errorf("expected 'in' after 'not' at %d", inTok.pos)
+			return nil, p.errorAt(inTok, "expected 'in' after 'not'").expecting("in")
 		}
 		return p.parseMembership(fieldTok.lit, OpNotIn)
 	}
 	if opTok.typ == tokenIn {
 		return p.parseMembership(fieldTok.lit, OpIn)
 	}
-	if opTok.typ != tokenOp {
-		return nil, fmt.Errorf("expected operator at %d", opTok.pos)
+	isContains := opTok.typ == tokenIdent && strings.ToLower(opTok.lit) == string(OpContains)
+	if opTok.typ != tokenOp && !isContains {
+		diagnostic := p.errorAt(opTok, "expected operator").expecting(operatorNames...)
+		if opTok.typ == tokenIdent {
+			diagnostic = diagnostic.suggesting(suggest(opTok.lit, []string{"in", "not in", "contains"})...)
+		}
+		return nil, diagnostic
 	}
-	compare, err := parseCompareOp(opTok.lit)
+	compare, err := parseCompareOp(strings.ToLower(opTok.lit))
 	if err != nil {
-		return nil, fmt.Errorf("invalid operator %q at %d", opTok.lit, opTok.pos)
+		return nil, p.errorAt(opTok, "invalid operator %q", opTok.lit).expecting(operatorNames...)
+	}
+	if next, _ := p.peek(); compare == OpEq && next.typ == tokenOp && next.lit == "=" && next.pos == opTok.end {
+		_, _ = p.next()
+		return nil, p.errorAt(token{pos: opTok.pos, end: next.end}, "invalid operator %q", "==").suggesting("=")
 	}
 	value, err := p.parseValue()
 	if err != nil {
@@ -203,7 +313,7 @@ func (p *parser) parseMembership(field string, op MembershipOp) (Expr, error) {
 				_, _ = p.next()
 				break
 			}
-			return nil, fmt.Errorf("expected ',' or ']' at %d", nextTok.pos)
+			return nil, p.errorAt(nextTok, "expected ',' or ']'").expecting(",", "]")
 		}
 		return PredicateExpr{Field: field, Op: op, List: values}, nil
 	}
@@ -212,8 +322,10 @@ func (p *parser) parseMembership(field string, op MembershipOp) (Expr, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := value.(FunctionValue); !ok {
-		return nil, fmt.Errorf("expected list after %q at %d", op, tok.pos)
+	switch value.(type) {
+	case FunctionValue, CalendarValue, RangeValue, TeamValue:
+	default:
+		return nil, p.errorAt(tok, "expected list after %q", op)
 	}
 	return PredicateExpr{Field: field, Op: op, Value: value}, nil
 }
@@ -231,28 +343,49 @@ func (p *parser) parseValue() (Value, error) {
 	case tokenNumber:
 		val, err := strconv.Atoi(tok.lit)
 		if err != nil {
-			return nil, fmt.Errorf("invalid number %q at %d", tok.lit, tok.pos)
+			return nil, p.errorAt(tok, "invalid number %q", tok.lit)
 		}
 		return NumberValue{Value: val}, nil
 	case tokenDate:
+		if strings.ContainsAny(tok.lit, "Tt") {
+			return p.parseDateTime(tok)
+		}
 		date, err := time.Parse("2006-01-02", tok.lit)
 		if err != nil {
-			return nil, fmt.Errorf("invalid date %q at %d", tok.lit, tok.pos)
+			return nil, p.errorAt(tok, "invalid date %q", tok.lit)
 		}
 		return DateValue{Value: date}, nil
 	case tokenDuration:
 		dur, err := parseDuration(tok.lit)
 		if err != nil {
-			return nil, fmt.Errorf("invalid duration %q at %d", tok.lit, tok.pos)
+			return nil, p.errorAt(tok, "invalid duration %q", tok.lit)
 		}
 		return DurationValue{Value: dur}, nil
 	case tokenIdent:
-		if strings.ToLower(tok.lit) == "last" {
+		name := strings.ToLower(tok.lit)
+		switch {
+		case name == "last":
 			return p.parseFunction(tok)
+		case name == "between":
+			return p.parseBetween(tok)
+		case name == "team":
+			return p.parseTeam(tok)
+		case isCalendarName(name):
+			return CalendarValue{Name: name}, nil
+		}
+		suggestions := suggest(name, append([]string{"last", "between", "team"}, calendarNames...))
+		if len(suggestions) == 0 {
+			suggestions = []string{quoteString(tok.lit)}
 		}
-		return nil, fmt.Errorf("expected value, got identifier %q at %d", tok.lit, tok.pos)
+		return nil, p.errorAt(tok, "expected value, got identifier %q", tok.lit).
+			expecting(valueKinds...).
+			suggesting(suggestions...)
+	case tokenMacro:
+		return nil, p.errorAt(tok, "expected value, got macro %q", "@"+tok.lit).
+			expecting(valueKinds...).
+			suggesting(quoteString("@" + tok.lit))
 	default:
-		return nil, fmt.Errorf("expected value at %d", tok.pos)
+		return nil, p.errorAt(tok, "expected value").expecting(valueKinds...)
 	}
 }
 
@@ -262,21 +395,122 @@ func (p *parser) parseFunction(name token) (Value, error) {
 		return nil, err
 	}
 	if tok.typ != tokenLParen {
-		return nil, fmt.Errorf("expected '(' after %q at %d", name.lit, tok.pos)
+		return nil, p.errorAt(tok, "expected '(' after %q", name.lit)
 	}
 	arg, err := p.parseValue()
 	if err != nil {
 		return nil, err
 	}
 	if _, ok := arg.(DurationValue); !ok {
-		return nil, fmt.Errorf("expected duration for %q at %d", name.lit, tok.pos)
+		return nil, p.errorAt(tok, "expected duration for %q", name.lit)
 	}
 	if tok, _ := p.next(); tok.typ != tokenRParen {
-		return nil, fmt.Errorf("expected ')' after function at %d", tok.pos)
+		return nil, p.errorAt(tok, "expected ')' after function")
 	}
 	return FunctionValue{Name: strings.ToLower(name.lit), Arg: arg}, nil
 }
 
+func (p *parser) parseTeam(name token) (Value, error) {
+	if tok, _ := p.next(); tok.typ != tokenLParen {
+		return nil, p.errorAt(tok, "expected '(' after %q", name.lit)
+	}
+	slug, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if slug.typ != tokenString || slug.lit == "" {
+		return nil, p.errorAt(slug, "expected quoted team such as \"org/team\"")
+	}
+	if tok, _ := p.next(); tok.typ != tokenRParen {
+		return nil, p.errorAt(tok, "expected ')' after team").expecting(")")
+	}
+	return TeamValue{Slug: slug.lit}, nil
+}
+
+func (p *parser) parseBetween(name token) (Value, error) {
+	if tok, _ := p.next(); tok.typ != tokenLParen {
+		return nil, p.errorAt(tok, "expected '(' after %q", name.lit)
+	}
+	from, err := p.parseBetweenBound(name)
+	if err != nil {
+		return nil, err
+	}
+	if tok, _ := p.next(); tok.typ != tokenComma {
+		return nil, p.errorAt(tok, "expected ',' in %q", name.lit)
+	}
+	to, err := p.parseBetweenBound(name)
+	if err != nil {
+		return nil, err
+	}
+	if tok, _ := p.next(); tok.typ != tokenRParen {
+		return nil, p.errorAt(tok, "expected ')' after function")
+	}
+	return RangeValue{From: from, To: to}, nil
+}
+
+func (p *parser) parseBetweenBound(name token) (Value, error) {
+	tok, _ := p.peek()
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	switch value.(type) {
+	case DateValue, DateTimeValue, DurationValue, CalendarValue:
+		return value, nil
+	default:
+		return nil, p.errorAt(tok, "expected date or duration for %q", name.lit)
+	}
+}
+
+func (p *parser) atOrderClause() bool {
+	tok, err := p.peek()
+	if err != nil {
+		return false
+	}
+	return tok.typ == tokenIdent && strings.ToLower(tok.lit) == "order"
+}
+
+func (p *parser) parseOrderClause() (OrderBy, error) {
+	_, _ = p.next() // order
+	tok, err := p.next()
+	if err != nil {
+		return OrderBy{}, err
+	}
+	if tok.typ != tokenIdent || strings.ToLower(tok.lit) != "by" {
+		return OrderBy{}, p.errorAt(tok, "expected 'by' after 'order'").expecting("by")
+	}
+	tok, err = p.next()
+	if err != nil {
+		return OrderBy{}, err
+	}
+	field := SortField(strings.ToLower(tok.lit))
+	switch field {
+	case SortUpdated, SortCreated, SortComments, SortReactions:
+	default:
+		return OrderBy{}, p.errorAt(tok, "expected one of updated, created, comments, reactions after 'order by'").
+			expecting(sortFieldNames...).
+			suggesting(suggest(tok.lit, sortFieldNames)...)
+	}
+	order := OrderBy{Field: field, Direction: SortDesc}
+	tok, err = p.peek()
+	if err != nil {
+		return OrderBy{}, err
+	}
+	if tok.typ == tokenIdent {
+		switch SortDirection(strings.ToLower(tok.lit)) {
+		case SortAsc:
+			order.Direction = SortAsc
+		case SortDesc:
+		default:
+			return OrderBy{}, p.errorAt(tok, "expected 'asc' or 'desc'").
+				expecting("asc", "desc").
+				suggesting(suggest(tok.lit, []string{"asc", "desc"})...)
+		}
+		_, _ = p.next()
+	}
+	return order, nil
+}
+
 func parseCompareOp(lit string) (CompareOp, error) {
 	switch lit {
 	case "=":
@@ -291,11 +525,34 @@ func parseCompareOp(lit string) (CompareOp, error) {
 		return OpLt, nil
 	case "<=":
 		return OpLte, nil
+	case "~":
+		return OpMatch, nil
+	case "contains":
+		return OpContains, nil
 	default:
 		return "", fmt.Errorf("unknown operator %q", lit)
 	}
 }
 
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// parseDateTime parses an ISO 8601 date-time. Without an offset it is read
+// as UTC, like plain dates.
+func (p *parser) parseDateTime(tok token) (Value, error) {
+	lit := strings.ToUpper(tok.lit)
+	for _, layout := range dateTimeLayouts {
+		if value, err := time.Parse(layout, lit); err == nil {
+			return DateTimeValue{Value: value}, nil
+		}
+	}
+	return nil, p.errorAt(tok, "invalid date-time %q", tok.lit)
+}
+
 func parseDuration(lit string) (time.Duration, error) {
 	if lit == "" {
 		return 0, fmt.Errorf("empty duration")
@@ -329,3 +586,17 @@ func parseDuration(lit string) (time.Duration, error) {
 	}
 	return time.Duration(sign) * dur, nil
 }
+
+var (
+	operatorNames  = []string{"=", "!=", ">", ">=", "<", "<=", "~", "contains", "in", "not in"}
+	valueKinds     = []string{"string", "number", "date", "duration", "true", "false"}
+	sortFieldNames = []string{string(SortUpdated), string(SortCreated), string(SortComments), string(SortReactions)}
+)
+
+func (p *parser) errorAt(tok token, format string, args ...any) Diagnostic {
+	end := tok.end
+	if end < tok.pos {
+		end = tok.pos
+	}
+	return p.lexer.diagnostic(tok.pos, end, fmt.Sprintf(format, args...))
+}
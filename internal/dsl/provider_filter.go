@@ -20,6 +20,12 @@ func extractProviderFilter(expr Expr, parentOp BinaryOp) (Expr, ProviderFilter,
 		return nil, ProviderFilter{}, false, nil
 	}
 	switch node := expr.(type) {
+	case OrderedExpr:
+		inner, filter, hasProvider, err := extractProviderFilter(node.Expr, parentOp)
+		if err != nil {
+			return nil, ProviderFilter{}, false, err
+		}
+		return OrderedExpr{Expr: inner, Order: node.Order}, filter, hasProvider, nil
 	case PredicateExpr:
 		if strings.ToLower(node.Field) != "provider" {
 			return node, ProviderFilter{}, false, nil
@@ -30,17 +36,17 @@ func extractProviderFilter(expr Expr, parentOp BinaryOp) (Expr, ProviderFilter,
 		}
 		return nil, filter, true, nil
 	case UnaryExpr:
-		if node.Negate {
-			return nil, ProviderFilter{}, false, fmt.Errorf("provider filters cannot be negated")
-		}
 		expr, filter, hasProvider, err := extractProviderFilter(node.Expr, parentOp)
 		if err != nil {
 			return nil, ProviderFilter{}, false, err
 		}
+		if !node.Negate {
+			return expr, filter, hasProvider, nil
+		}
 		if hasProvider {
 			return nil, ProviderFilter{}, false, fmt.Errorf("provider filters cannot be negated")
 		}
-		return expr, filter, false, nil
+		return UnaryExpr{Negate: true, Expr: expr}, filter, false, nil
 	case BinaryExpr:
 		leftExpr, leftFilter, leftHas, err := extractProviderFilter(node.Left, node.Op)
 		if err != nil {
@@ -3,15 +3,32 @@ package dsl
 import "strings"
 
 var reservedWords = map[string]struct{}{
-	"and":   {},
-	"or":    {},
-	"not":   {},
-	"in":    {},
-	"true":  {},
-	"false": {},
-	"last":  {},
+	"and":      {},
+	"or":       {},
+	"not":      {},
+	"in":       {},
+	"true":     {},
+	"false":    {},
+	"last":     {},
+	"order":    {},
+	"by":       {},
+	"asc":      {},
+	"desc":     {},
+	"use":      {},
+	"contains": {},
+	"team":     {},
+
+	"between":    {},
+	"today":      {},
+	"yesterday":  {},
+	"this_week":  {},
+	"last_week":  {},
+	"this_month": {},
+	"last_month": {},
 }
 
+// IsReserved reports whether word is a keyword of the filter language, which
+// can't name a field.
 func IsReserved(word string) bool {
 	_, ok := reservedWords[strings.ToLower(word)]
 	return ok
@@ -0,0 +1,293 @@
+package dsl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UnsupportedExpressionError is returned when a provider cannot express the
+// shape of a filter (OR groups, negated groups), as opposed to a single
+// predicate it does not understand.
+type UnsupportedExpressionError struct {
+	Provider string
+	Reason   string
+}
+
+func (err UnsupportedExpressionError) Error() string {
+	return err.Reason
+}
+
+// UnscopedQueryError is returned when the part of a filter a provider can
+// search for only narrows the search down by broad fields such as state, so
+// it would search every item and match the rest of the filter locally.
+type UnscopedQueryError struct {
+	Provider string
+}
+
+func (err UnscopedQueryError) Error() string {
+	return fmt.Sprintf(
+		"%s can only search for the state of this filter; add a project, author or other predicate it can search for",
+		err.Provider)
+}
+
+// LocalEvaluationError is returned when part of a filter a provider can't
+// search for can't be matched locally either, because its items are fetched
+// without the data the field needs.
+type LocalEvaluationError struct {
+	Provider string
+	Field    string
+}
+
+func (err LocalEvaluationError) Error() string {
+	return fmt.Sprintf("%s cannot be evaluated locally for %s items", err.Field, err.Provider)
+}
+
+// githubMaxOperators is how many AND, OR and NOT operators a GitHub search
+// may use.
+const githubMaxOperators = 5
+
+// broadFields are the fields that don't narrow a search down enough to
+// match the rest of a filter locally.
+var broadFields = []string{"state", "type", "draft", "archived"}
+
+// IsTranslationGap reports whether err means the provider cannot express part
+// of a filter, so that part can still be evaluated locally.
+func IsTranslationGap(err error) bool {
+	var predicateErr UnsupportedPredicateError
+	if errors.As(err, &predicateErr) {
+		return true
+	}
+	var exprErr UnsupportedExpressionError
+	return errors.As(err, &exprErr)
+}
+
+// TranslateGitHubPartial translates every top-level conjunct GitHub
+// understands and returns the rest as a residual expression to be evaluated
+// against the fetched items. Title and body predicates are both translated
+// and kept in the residual, since GitHub only searches for their words. An
+// `in` list with more values than a search may OR together is split into
+// chunks.
+func TranslateGitHubPartial(expr Expr, now time.Time) (GitHubQuery, error) {
+	normalized, order := SplitOrder(Normalize(expr))
+	withoutProviders, providers, err := ExtractProviderFilter(normalized)
+	if err != nil {
+		return GitHubQuery{}, err
+	}
+	parts := []string{}
+	translated := []Expr{}
+	residual := []Expr{}
+	operators := 0
+	var chunked Expr
+	for _, conjunct := range Conjuncts(withoutProviders) {
+		query, err := buildGitHubQuery(conjunct, now)
+		if err != nil {
+			if !IsTranslationGap(err) {
+				return GitHubQuery{}, err
+			}
+			residual = append(residual, conjunct)
+			continue
+		}
+		// a conjunct that would take the search over GitHub's limit of
+		// operators is split into several searches, or matched locally
+		n := strings.Count(query, " OR ")
+		if operators+n > githubMaxOperators {
+			if chunked == nil && isChunkable(conjunct) {
+				chunked = conjunct
+				continue
+			}
+			residual = append(residual, conjunct)
+			continue
+		}
+		operators += n
+		parts = append(parts, query)
+		translated = append(translated, conjunct)
+		if needsLocalCheck(conjunct) {
+			residual = append(residual, conjunct)
+		}
+	}
+	var chunks []string
+	if chunked != nil {
+		chunks, err = githubChunks(chunked.(PredicateExpr), githubMaxOperators-operators, now)
+		if err != nil {
+			return GitHubQuery{}, err
+		}
+		translated = append(translated, chunked)
+	}
+	if len(residual) > 0 && !scopesSearch(translated) {
+		return GitHubQuery{}, UnscopedQueryError{Provider: "github"}
+	}
+	parts = append(parts, githubTextScope(translated))
+	if order != nil {
+		parts = append(parts, orderToGitHub(*order))
+	}
+	return GitHubQuery{
+		Query:          strings.Join(filterEmpty(parts...), " "),
+		Chunks:         chunks,
+		ProviderFilter: providers,
+		Residual:       Conjoin(residual),
+		Order:          order,
+	}, nil
+}
+
+// TranslateGitLabPartial is the GitLab counterpart of TranslateGitHubPartial.
+// Conjuncts that would overwrite a query parameter set by an earlier one are
+// also left to the residual.
+func TranslateGitLabPartial(expr Expr, now time.Time) (GitLabQuery, error) {
+	normalized, order := SplitOrder(Normalize(expr))
+	withoutProviders, providers, err := ExtractProviderFilter(normalized)
+	if err != nil {
+		return GitLabQuery{}, err
+	}
+	params := map[string]string{}
+	projectPath := ""
+	translated := []Expr{}
+	residual := []Expr{}
+	for _, conjunct := range Conjuncts(withoutProviders) {
+		partParams := map[string]string{}
+		partProject := ""
+		if err := buildGitLabQuery(conjunct, now, partParams, &partProject); err != nil {
+			if !IsTranslationGap(err) {
+				return GitLabQuery{}, err
+			}
+			residual = append(residual, conjunct)
+			continue
+		}
+		if conflictingParams(params, partParams) {
+			residual = append(residual, conjunct)
+			continue
+		}
+		if partProject != "" {
+			if projectPath != "" && projectPath != partProject {
+				return GitLabQuery{}, fmt.Errorf("multiple project predicates are not supported")
+			}
+			projectPath = partProject
+		}
+		for key, value := range partParams {
+			params[key] = value
+		}
+		translated = append(translated, conjunct)
+		if needsLocalCheck(conjunct) {
+			residual = append(residual, conjunct)
+		}
+	}
+	if len(residual) > 0 && !scopesSearch(translated) {
+		return GitLabQuery{}, UnscopedQueryError{Provider: "gitlab"}
+	}
+	// GitLab items are fetched without their commenters
+	if ReferencesField(Conjoin(residual), "involves") {
+		return GitLabQuery{}, LocalEvaluationError{Provider: "gitlab", Field: "involves"}
+	}
+	if order != nil {
+		orderToGitLab(*order, params)
+	}
+	return GitLabQuery{
+		ProjectPath:    projectPath,
+		Params:         params,
+		ProviderFilter: providers,
+		Residual:       Conjoin(residual),
+		Order:          order,
+	}, nil
+}
+
+// isChunkable reports whether expr is an `in` list GitHub can search for a
+// part of at a time.
+func isChunkable(expr Expr) bool {
+	pred, ok := expr.(PredicateExpr)
+	return ok && pred.Op == OpIn && len(pred.List) > 1
+}
+
+// githubChunks splits the values of an `in` list into OR'd qualifiers, each
+// within the given number of operators.
+func githubChunks(pred PredicateExpr, operators int, now time.Time) ([]string, error) {
+	size := operators + 1
+	field := strings.ToLower(pred.Field)
+	var chunks []string
+	for start := 0; start < len(pred.List); start += size {
+		chunk, err := listPredicateToGitHub(field, OpIn, pred.List[start:min(start+size, len(pred.List))], now)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// scopesSearch reports whether the translated conjuncts narrow a search down
+// by more than broad fields.
+func scopesSearch(translated []Expr) bool {
+	for _, conjunct := range translated {
+		if !onlyReferences(conjunct, broadFields) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyReferences reports whether every predicate in expr uses one of fields.
+func onlyReferences(expr Expr, fields []string) bool {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		return onlyReferences(node.Left, fields) && onlyReferences(node.Right, fields)
+	case UnaryExpr:
+		return onlyReferences(node.Expr, fields)
+	case PredicateExpr:
+		return slices.Contains(fields, strings.ToLower(node.Field))
+	default:
+		return true
+	}
+}
+
+// ReferencesField reports whether any predicate in expr uses field.
+func ReferencesField(expr Expr, field string) bool {
+	switch node := expr.(type) {
+	case BinaryExpr:
+		return ReferencesField(node.Left, field) || ReferencesField(node.Right, field)
+	case UnaryExpr:
+		return ReferencesField(node.Expr, field)
+	case OrderedExpr:
+		return ReferencesField(node.Expr, field)
+	case PredicateExpr:
+		return strings.EqualFold(node.Field, field)
+	default:
+		return false
+	}
+}
+
+// Conjuncts flattens the top-level AND chain of expr.
+func Conjuncts(expr Expr) []Expr {
+	if expr == nil {
+		return nil
+	}
+	if node, ok := expr.(BinaryExpr); ok && node.Op == OpAnd {
+		return append(Conjuncts(node.Left), Conjuncts(node.Right)...)
+	}
+	return []Expr{expr}
+}
+
+// Conjoin joins exprs with AND. It returns nil for an empty slice.
+func Conjoin(exprs []Expr) Expr {
+	var out Expr
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+		if out == nil {
+			out = expr
+			continue
+		}
+		out = BinaryExpr{Op: OpAnd, Left: out, Right: expr}
+	}
+	return out
+}
+
+func conflictingParams(params, partParams map[string]string) bool {
+	for key, value := range partParams {
+		if existing, ok := params[key]; ok && existing != value {
+			return true
+		}
+	}
+	return false
+}
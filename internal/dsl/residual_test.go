@@ -0,0 +1,133 @@
+package dsl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTranslateGitHubPartialResidual(t *testing.T) {
+	expr, err := ParseFilter(`project = "org/repo" and state = "open" and not (label = "bug" or label = "wip") and type != "pr"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "repo:org/repo is:open" {
+		t.Fatalf("unexpected query: %q", query.Query)
+	}
+	if got := len(Conjuncts(query.Residual)); got != 2 {
+		t.Fatalf("expected 2 residual conjuncts, got %d", got)
+	}
+}
+
+func TestTranslateGitLabPartialResidual(t *testing.T) {
+	expr, err := ParseFilter(`state = "opened" and label != "wip" and archived = false and label = "bug"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Params["state"] != "opened" || query.Params["labels"] != "bug" {
+		t.Fatalf("unexpected params: %#v", query.Params)
+	}
+	if got := len(Conjuncts(query.Residual)); got != 2 {
+		t.Fatalf("expected 2 residual conjuncts, got %d", got)
+	}
+}
+
+func TestTranslateGitLabPartialConflictingParams(t *testing.T) {
+	expr, err := ParseFilter(`author = "alice" and author = "bob"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Params["author_username"] != "alice" {
+		t.Fatalf("unexpected author param: %q", query.Params["author_username"])
+	}
+	pred, ok := query.Residual.(PredicateExpr)
+	if !ok || pred.Value.(StringValue).Value != "bob" {
+		t.Fatalf("unexpected residual: %#v", query.Residual)
+	}
+}
+
+func TestTranslateGitLabPartialKeepsHardErrors(t *testing.T) {
+	expr, err := ParseFilter(`project = "a/b" and project = "c/d"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, err = TranslateGitLabPartial(expr, time.Now())
+	if err == nil || err.Error() != "multiple project predicates are not supported" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTranslatePartialKeepsUserListsServerSide(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" and author in ["a", "b"]`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "is:open (author:a OR author:b)" || query.Residual != nil {
+		t.Fatalf("unexpected query: %q, residual %v", query.Query, query.Residual)
+	}
+}
+
+func TestTranslatePartialRefusesUnscopedSearches(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" and author in ["a", "b"]`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var unscoped UnscopedQueryError
+	if _, err := TranslateGitLabPartial(expr, time.Now()); !errors.As(err, &unscoped) {
+		t.Fatalf("expected an unscoped query error, got %v", err)
+	}
+
+}
+
+func TestTranslateGitLabPartialRefusesInvolvesResidual(t *testing.T) {
+	expr, err := ParseFilter(`project = "org/repo" and involves = "alice"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var local LocalEvaluationError
+	if _, err := TranslateGitLabPartial(expr, time.Now()); !errors.As(err, &local) || local.Field != "involves" {
+		t.Fatalf("expected involves to be refused, got %v", err)
+	}
+}
+
+func TestTranslateGitHubPartialChunksLongLists(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" and (label = "a" or label = "b" or label = "c") and ` +
+		`author in ["a", "b", "c", "d", "e"] and assignee in ["a", "b", "c", "d", "e"]`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "is:open ((label:a OR label:b) OR label:c)" {
+		t.Fatalf("unexpected query: %q", query.Query)
+	}
+	want := []string{
+		"(author:a OR author:b OR author:c OR author:d)",
+		"(author:e)",
+	}
+	if !reflect.DeepEqual(query.Chunks, want) {
+		t.Fatalf("unexpected chunks: %q", query.Chunks)
+	}
+	if got := Format(query.Residual); got != `assignee in ["a", "b", "c", "d", "e"]` {
+		t.Fatalf("expected the second long list to be matched locally, got %q", got)
+	}
+}
@@ -0,0 +1,130 @@
+package dsl
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// textFields are matched by providers as full-text searches, which find
+// words rather than substrings or patterns. Their predicates only narrow
+// down what is fetched and are always evaluated locally as well.
+var textFields = []string{"title", "body"}
+
+func isTextField(field string) bool {
+	for _, name := range textFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsLocalCheck reports whether expr contains a predicate a provider can
+// only approximate.
+func needsLocalCheck(expr Expr) bool {
+	for _, field := range textFields {
+		if ReferencesField(expr, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchWords returns the words an item has to contain to match a text
+// predicate, lowercased, or false when there are none to search for.
+func searchWords(op CompareOp, value Value) ([]string, bool) {
+	str, err := stringValue(value)
+	if err != nil {
+		return nil, false
+	}
+	var found []string
+	switch op {
+	case OpEq, OpContains:
+		found = words(str)
+	case OpMatch:
+		found = patternWords(str)
+	}
+	return found, len(found) > 0
+}
+
+func words(value string) []string {
+	fields := strings.FieldsFunc(value, func(ch rune) bool {
+		return !unicode.IsLetter(ch) && !unicode.IsDigit(ch)
+	})
+	for i, field := range fields {
+		fields[i] = strings.ToLower(field)
+	}
+	return fields
+}
+
+// patternWords returns the whole words every match of pattern contains. A
+// word at the edge of a literal only counts when an anchor or word boundary
+// keeps it from being part of a longer word.
+func patternWords(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	re = re.Simplify()
+	for re.Op == syntax.OpCapture {
+		re = re.Sub[0]
+	}
+	parts := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		parts = re.Sub
+	}
+	var out []string
+	for i, part := range parts {
+		if part.Op != syntax.OpLiteral {
+			continue
+		}
+		literal := string(part.Rune)
+		found := words(literal)
+		if len(found) > 0 && isWordRune(part.Rune[len(part.Rune)-1]) && !(i+1 < len(parts) && isBoundary(parts[i+1])) {
+			found = found[:len(found)-1]
+		}
+		if len(found) > 0 && isWordRune(part.Rune[0]) && !(i > 0 && isBoundary(parts[i-1])) {
+			found = found[1:]
+		}
+		out = append(out, found...)
+	}
+	return out
+}
+
+func isBoundary(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary:
+		return true
+	default:
+		return false
+	}
+}
+
+func isWordRune(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch)
+}
+
+// githubTextScope returns the in: qualifier that limits the search terms of
+// the title and body predicates in exprs to those fields. Free-text
+// predicates search everywhere, so there is no qualifier when one is present.
+func githubTextScope(exprs []Expr) string {
+	var scopes []string
+	for _, field := range textFields {
+		for _, expr := range exprs {
+			if ReferencesField(expr, field) {
+				scopes = append(scopes, field)
+				break
+			}
+		}
+	}
+	for _, expr := range exprs {
+		if ReferencesField(expr, "text") {
+			return ""
+		}
+	}
+	if len(scopes) == 0 {
+		return ""
+	}
+	return "in:" + strings.Join(scopes, ",")
+}
@@ -20,10 +20,13 @@ const (
 	tokenOr
 	tokenNot
 	tokenIn
+	tokenMacro
 )
 
+// token positions are rune offsets into the lexer's input; end is exclusive.
 type token struct {
 	typ tokenType
 	lit string
 	pos int
+	end int
 }
@@ -2,13 +2,19 @@ package dsl
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
 
 type GitHubQuery struct {
-	Query          string
+	Query string
+	// Chunks split the search in several, each Query with one of them, when
+	// a list has too many values for a single search.
+	Chunks         []string
 	ProviderFilter ProviderFilter
+	Residual       Expr
+	Order          *OrderBy
 }
 
 type UnsupportedPredicateError struct {
@@ -22,7 +28,7 @@ func (err UnsupportedPredicateError) Error() string {
 }
 
 func TranslateGitHub(expr Expr, now time.Time) (GitHubQuery, error) {
-	normalized := Normalize(expr)
+	normalized, order := SplitOrder(Normalize(expr))
 	withoutProviders, providers, err := ExtractProviderFilter(normalized)
 	if err != nil {
 		return GitHubQuery{}, err
@@ -31,7 +37,11 @@ func TranslateGitHub(expr Expr, now time.Time) (GitHubQuery, error) {
 	if err != nil {
 		return GitHubQuery{}, err
 	}
-	return GitHubQuery{Query: query, ProviderFilter: providers}, nil
+	query = strings.Join(filterEmpty(query, githubTextScope(Conjuncts(withoutProviders))), " ")
+	if order != nil {
+		query = strings.Join(filterEmpty(query, orderToGitHub(*order)), " ")
+	}
+	return GitHubQuery{Query: query, ProviderFilter: providers, Order: order}, nil
 }
 
 func buildGitHubQuery(expr Expr, now time.Time) (string, error) {
@@ -65,7 +75,10 @@ func buildGitHubQuery(expr Expr, now time.Time) (string, error) {
 		}
 		pred, ok := node.Expr.(PredicateExpr)
 		if !ok {
-			return "", fmt.Errorf("negation only supported on predicates")
+			return "", UnsupportedExpressionError{Provider: "github", Reason: "negation only supported on predicates"}
+		}
+		if isTextField(pred.Field) {
+			return "", UnsupportedExpressionError{Provider: "github", Reason: "negated text searches are evaluated locally"}
 		}
 		value, err := predicateToGitHub(pred, now)
 		if err != nil {
@@ -133,6 +146,12 @@ func comparePredicateToGitHub(field string, op CompareOp, value Value, now time.
 		}
 		return fmt.Sprintf("is:%s", str), nil
 	case "author", "assignee", "review_requested", "involves":
+		if team, ok := value.(TeamValue); ok {
+			if field != "review_requested" {
+				return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
+			}
+			return formatNegatableQualifier("team-review-requested", team.Slug, op)
+		}
 		str, err := stringValue(value)
 		if err != nil {
 			return "", err
@@ -150,6 +169,21 @@ func comparePredicateToGitHub(field string, op CompareOp, value Value, now time.
 			return "", err
 		}
 		return formatNegatableQualifier("label", str, op)
+	case "head", "base":
+		str, err := stringValue(value)
+		if err != nil {
+			return "", err
+		}
+		if isGlob(str) {
+			return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
+		}
+		return formatNegatableQualifier(field, str, op)
+	case "ci":
+		status, err := ciStatusValue(value)
+		if err != nil {
+			return "", err
+		}
+		return formatNegatableQualifier("status", status, op)
 	case "draft":
 		boolean, err := boolValue(value)
 		if err != nil {
@@ -186,7 +220,18 @@ func comparePredicateToGitHub(field string, op CompareOp, value Value, now time.
 		if op != OpEq {
 			return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
 		}
+		if strings.Contains(str, ":") {
+			// quoted so that GitHub doesn't read it as a qualifier
+			return fmt.Sprintf("%q", str), nil
+		}
 		return quoteIfNeeded(str), nil
+	case "title", "body":
+		// in:title or in:body is added for the whole query by githubTextScope
+		words, ok := searchWords(op, value)
+		if !ok || op == OpNe {
+			return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
+		}
+		return strings.Join(words, " "), nil
 	default:
 		return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
 	}
@@ -197,7 +242,7 @@ func listPredicateToGitHub(field string, op MembershipOp, values []Value, now ti
 		return "", fmt.Errorf("empty list for %s", field)
 	}
 	switch field {
-	case "label", "project", "state":
+	case "label", "project", "state", "head", "base", "ci", "author", "assignee", "review_requested", "involves":
 		parts := make([]string, 0, len(values))
 		for _, val := range values {
 			part, err := comparePredicateToGitHub(field, OpEq, val, now)
@@ -235,6 +280,9 @@ func formatNegatableQualifier(qualifier, value string, op CompareOp) (string, er
 }
 
 func formatDateQualifier(field string, op CompareOp, value Value, now time.Time) (string, error) {
+	if isSpanValue(value) {
+		return formatSpanQualifier(field, op, value, now)
+	}
 	date, err := dateFromValue(value, now)
 	if err != nil {
 		return "", err
@@ -253,6 +301,31 @@ func formatDateQualifier(field string, op CompareOp, value Value, now time.Time)
 	return fmt.Sprintf("%s:%s%s", field, operator, date), nil
 }
 
+func formatSpanQualifier(field string, op CompareOp, value Value, now time.Time) (string, error) {
+	span, err := resolveTimeSpan(value, now)
+	if err != nil {
+		return "", err
+	}
+	first, last := githubSpanBounds(span)
+	switch op {
+	case OpEq:
+		if first == last {
+			return fmt.Sprintf("%s:%s", field, first), nil
+		}
+		return fmt.Sprintf("%s:%s..%s", field, first, last), nil
+	case OpGt:
+		return fmt.Sprintf("%s:>%s", field, last), nil
+	case OpGte:
+		return fmt.Sprintf("%s:>=%s", field, first), nil
+	case OpLt:
+		return fmt.Sprintf("%s:<%s", field, first), nil
+	case OpLte:
+		return fmt.Sprintf("%s:<=%s", field, last), nil
+	default:
+		return "", UnsupportedPredicateError{Provider: "github", Field: field, Op: op}
+	}
+}
+
 func dateFromValue(value Value, now time.Time) (string, error) {
 	switch val := value.(type) {
 	case DateValue:
@@ -286,6 +359,20 @@ func stringValues(values []Value) ([]string, error) {
 	return out, nil
 }
 
+var ciStatuses = []string{"success", "failure", "pending"}
+
+func ciStatusValue(value Value) (string, error) {
+	str, err := stringValue(value)
+	if err != nil {
+		return "", err
+	}
+	status := strings.ToLower(str)
+	if !slices.Contains(ciStatuses, status) {
+		return "", fmt.Errorf("ci must be one of %s, got %q", strings.Join(ciStatuses, ", "), str)
+	}
+	return status, nil
+}
+
 func boolValue(value Value) (bool, error) {
 	switch val := value.(type) {
 	case BoolValue:
@@ -295,6 +382,12 @@ func boolValue(value Value) (bool, error) {
 	}
 }
 
+// isGlob reports whether a branch value uses glob syntax, which neither
+// provider can search for.
+func isGlob(value string) bool {
+	return strings.ContainsAny(value, "*?[")
+}
+
 func filterEmpty(values ...string) []string {
 	out := make([]string, 0, len(values))
 	for _, value := range values {
@@ -10,10 +10,12 @@ type GitLabQuery struct {
 	ProjectPath    string
 	Params         map[string]string
 	ProviderFilter ProviderFilter
+	Residual       Expr
+	Order          *OrderBy
 }
 
 func TranslateGitLab(expr Expr, now time.Time) (GitLabQuery, error) {
-	normalized := Normalize(expr)
+	normalized, order := SplitOrder(Normalize(expr))
 	withoutProviders, providers, err := ExtractProviderFilter(normalized)
 	if err != nil {
 		return GitLabQuery{}, err
@@ -25,10 +27,14 @@ func TranslateGitLab(expr Expr, now time.Time) (GitLabQuery, error) {
 			return GitLabQuery{}, err
 		}
 	}
+	if order != nil {
+		orderToGitLab(*order, params)
+	}
 	return GitLabQuery{
 		ProjectPath:    projectPath,
 		Params:         params,
 		ProviderFilter: providers,
+		Order:          order,
 	}, nil
 }
 
@@ -36,7 +42,7 @@ func buildGitLabQuery(expr Expr, now time.Time, params map[string]string, projec
 	switch node := expr.(type) {
 	case BinaryExpr:
 		if node.Op != OpAnd {
-			return fmt.Errorf("gitlab translation only supports AND predicates")
+			return UnsupportedExpressionError{Provider: "gitlab", Reason: "gitlab translation only supports AND predicates"}
 		}
 		if err := buildGitLabQuery(node.Left, now, params, projectPath); err != nil {
 			return err
@@ -44,7 +50,7 @@ func buildGitLabQuery(expr Expr, now time.Time, params map[string]string, projec
 		return buildGitLabQuery(node.Right, now, params, projectPath)
 	case UnaryExpr:
 		if node.Negate {
-			return fmt.Errorf("gitlab translation does not support negation")
+			return UnsupportedExpressionError{Provider: "gitlab", Reason: "gitlab translation does not support negation"}
 		}
 		return buildGitLabQuery(node.Expr, now, params, projectPath)
 	case PredicateExpr:
@@ -142,6 +148,26 @@ func comparePredicateToGitLab(
 		}
 		params["labels"] = str
 		return nil
+	case "ci":
+		// The merge request list API has no pipeline status parameter.
+		if _, err := ciStatusValue(value); err != nil {
+			return err
+		}
+		return UnsupportedPredicateError{Provider: "gitlab", Field: field, Op: op}
+	case "head", "base":
+		str, err := stringValue(value)
+		if err != nil {
+			return err
+		}
+		if op != OpEq || isGlob(str) {
+			return UnsupportedPredicateError{Provider: "gitlab", Field: field, Op: op}
+		}
+		key := map[string]string{
+			"head": "source_branch",
+			"base": "target_branch",
+		}[field]
+		params[key] = str
+		return nil
 	case "draft":
 		boolean, err := boolValue(value)
 		if err != nil {
@@ -163,6 +189,15 @@ func comparePredicateToGitLab(
 			return UnsupportedPredicateError{Provider: "gitlab", Field: field, Op: op}
 		}
 		params["search"] = str
+		params["in"] = "title,description"
+		return nil
+	case "title", "body":
+		words, ok := searchWords(op, value)
+		if !ok || op == OpNe {
+			return UnsupportedPredicateError{Provider: "gitlab", Field: field, Op: op}
+		}
+		params["search"] = strings.Join(words, " ")
+		params["in"] = map[string]string{"title": "title", "body": "description"}[field]
 		return nil
 	case "type":
 		if op != OpEq {
@@ -195,6 +230,9 @@ func listPredicateToGitLab(field string, op MembershipOp, values []Value, params
 }
 
 func datePredicateToGitLab(field string, op CompareOp, value Value, now time.Time, params map[string]string) error {
+	if isSpanValue(value) {
+		return spanPredicateToGitLab(field, op, value, now, params)
+	}
 	date, err := dateFromValue(value, now)
 	if err != nil {
 		return err
@@ -214,3 +252,30 @@ func datePredicateToGitLab(field string, op CompareOp, value Value, now time.Tim
 	}
 	return nil
 }
+
+// spanPredicateToGitLab maps a span onto GitLab's inclusive _after and
+// _before parameters.
+func spanPredicateToGitLab(field string, op CompareOp, value Value, now time.Time, params map[string]string) error {
+	span, err := resolveTimeSpan(value, now)
+	if err != nil {
+		return err
+	}
+	afterKey := fmt.Sprintf("%s_after", field)
+	beforeKey := fmt.Sprintf("%s_before", field)
+	switch op {
+	case OpGt:
+		params[afterKey] = formatGitLabTime(span.last().Add(time.Second))
+	case OpGte:
+		params[afterKey] = formatGitLabTime(span.start)
+	case OpLt:
+		params[beforeKey] = formatGitLabTime(span.start.Add(-time.Second))
+	case OpLte:
+		params[beforeKey] = formatGitLabTime(span.last())
+	case OpEq:
+		params[afterKey] = formatGitLabTime(span.start)
+		params[beforeKey] = formatGitLabTime(span.last())
+	default:
+		return UnsupportedPredicateError{Provider: "gitlab", Field: field, Op: op}
+	}
+	return nil
+}
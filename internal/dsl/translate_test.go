@@ -126,3 +126,137 @@ func TestTranslateGitLabMultipleProjectsError(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestTranslateBranchPredicates(t *testing.T) {
+	expr, err := ParseFilter(`base in ["main", "develop"] and head != "wip"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHub(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "(base:main OR base:develop) -head:wip" {
+		t.Fatalf("unexpected query: %q", query.Query)
+	}
+
+	expr, err = ParseFilter(`base = "main" and head = "feature/login"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	out, err := TranslateGitLab(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if out.Params["target_branch"] != "main" || out.Params["source_branch"] != "feature/login" {
+		t.Fatalf("unexpected params: %#v", out.Params)
+	}
+}
+
+func TestTranslateBranchGlobFallsBackToResidual(t *testing.T) {
+	expr, err := ParseFilter(`project = "org/repo" and state = "open" and base = "release/*"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "repo:org/repo is:open" || query.Residual == nil {
+		t.Fatalf("expected glob to be matched locally: %#v", query)
+	}
+
+	matched, err := Evaluate(query.Residual, fakeSubject{"base": "release/2.1"}, time.Now())
+	if err != nil || !matched {
+		t.Fatalf("expected release branch to match: %t %v", matched, err)
+	}
+	matched, err = Evaluate(query.Residual, fakeSubject{"base": "main"}, time.Now())
+	if err != nil || matched {
+		t.Fatalf("expected main not to match: %t %v", matched, err)
+	}
+}
+
+func TestTranslateCIPredicate(t *testing.T) {
+	expr, err := ParseFilter(`author = "me" and ci = "failure"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHub(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "author:@me status:failure" {
+		t.Fatalf("unexpected query: %q", query.Query)
+	}
+
+	out, err := TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if !ReferencesField(out.Residual, "ci") {
+		t.Fatalf("expected ci to be filtered locally: %#v", out.Residual)
+	}
+
+	expr, err = ParseFilter(`ci = "broken"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, err = TranslateGitHub(expr, time.Now())
+	if err == nil || err.Error() != `ci must be one of success, failure, pending, got "broken"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTranslateTextFields(t *testing.T) {
+	expr, err := ParseFilter(`state = "open" and title ~ "^chore\\(deps\\)" and body contains "Security fix" and not title ~ "bot\\]$"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "is:open chore deps security fix in:title,body" {
+		t.Fatalf("unexpected query: %q", query.Query)
+	}
+	want := `title ~ "^chore\\(deps\\)" and body contains "Security fix" and not title ~ "bot\\]$"`
+	if got := Format(query.Residual); got != want {
+		t.Fatalf("expected residual %q, got %q", want, got)
+	}
+
+	out, err := TranslateGitLabPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if out.Params["search"] != "chore deps" || out.Params["in"] != "title" {
+		t.Fatalf("unexpected params: %#v", out.Params)
+	}
+}
+
+func TestTranslateTextFieldsWithoutWords(t *testing.T) {
+	expr, err := ParseFilter(`text = "flaky" and title ~ "depend"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHubPartial(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != "flaky" || !ReferencesField(query.Residual, "title") {
+		t.Fatalf("expected the pattern to be matched locally: %#v", query)
+	}
+}
+
+func TestTranslateTextWithColonIsQuoted(t *testing.T) {
+	expr, err := ParseFilter(`text = "sort:"`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	query, err := TranslateGitHub(expr, time.Now())
+	if err != nil {
+		t.Fatalf("translate error: %v", err)
+	}
+	if query.Query != `"sort:"` {
+		t.Fatalf("expected the text to be quoted, got %q", query.Query)
+	}
+}
@@ -1,6 +1,7 @@
 package dsl
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -17,52 +18,91 @@ func (err ValidationError) Error() string {
 	return fmt.Sprintf("%s (%s)", err.Reason, err.Hint)
 }
 
-func ValidateFilter(filter string) error {
+// ValidateFilter returns the first problem CheckFilter finds in filter.
+func ValidateFilter(filter string, defs map[string]string) error {
+	if errs := CheckFilter(filter, defs); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// CheckFilter returns every problem in filter: a legacy qualifier or a
+// broken macro reference on its own, and otherwise each located diagnostic.
+func CheckFilter(filter string, defs map[string]string) []error {
 	if strings.TrimSpace(filter) == "" {
 		return nil
 	}
 
 	if err := detectLegacyQualifiers(filter); err != nil {
-		return err
+		return []error{err}
 	}
 
-	if _, err := ParseFilter(filter); err != nil {
-		return ValidationError{
+	_, err := ParseFilterWithMacros(filter, defs)
+	var macroErr MacroError
+	if errors.As(err, &macroErr) {
+		return []error{err}
+	}
+	diagnostics := DiagnoseWithMacros(filter, defs)
+	if len(diagnostics) == 0 && err != nil {
+		return []error{ValidationError{
 			Reason: err.Error(),
 			Hint:   "use quoted strings and the documented DSL operators",
-		}
+		}}
 	}
+	errs := make([]error, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		errs = append(errs, diagnosticError(diagnostic))
+	}
+	return errs
+}
 
-	return nil
+// IsLegacyFilter reports whether filter uses GitHub search qualifiers such
+// as `is:open` instead of the DSL.
+func IsLegacyFilter(filter string) bool {
+	return detectLegacyQualifiers(filter) != nil
+}
+
+func diagnosticError(diagnostic Diagnostic) ValidationError {
+	err := ValidationError{Reason: diagnostic.Error()}
+	switch {
+	case len(diagnostic.Suggestions) > 0:
+	case len(diagnostic.Expected) > 0 && len(diagnostic.Expected) <= 8:
+		err.Hint = "expected one of " + strings.Join(diagnostic.Expected, ", ")
+	default:
+		err.Hint = "use quoted strings and the documented DSL operators"
+	}
+	return err
 }
 
+// detectLegacyQualifiers reports the first `:` the lexer can't make sense
+// of. Running the lexer rather than scanning for colons keeps the ones in
+// strings and date-time literals from counting.
 func detectLegacyQualifiers(filter string) error {
-	inQuote := false
-	escaped := false
-	for i, r := range filter {
-		switch r {
-		case '\\':
-			if inQuote {
-				escaped = !escaped
+	l := newLexer(filter)
+	for {
+		l.skipWhitespace()
+		start := l.pos
+		tok, err := l.nextToken()
+		if err == nil {
+			if tok.typ == tokenEOF {
+				return nil
 			}
-		case '"':
-			if !escaped {
-				inQuote = !inQuote
-			}
-			escaped = false
-		case ':':
-			if !inQuote {
-				token := extractToken(filter, i)
-				return ValidationError{
-					Reason: fmt.Sprintf("filters must use the DSL; legacy qualifier %q detected", token),
-					Hint:   `use "field = value" or "field in [..]" syntax`,
-				}
+			continue
+		}
+		switch {
+		case l.pos < len(l.input) && l.input[l.pos] == ':':
+			token := extractToken(filter, l.offset(l.pos))
+			return ValidationError{
+				Reason: fmt.Sprintf("filters must use the DSL; legacy qualifier %q detected", token),
+				Hint:   `use "field = value" or "field in [..]" syntax, or run "gh dash migrate-config"`,
 			}
-		default:
-			escaped = false
+		case l.input[start] == '"':
+			// the rest of the filter is an unterminated string
+			return nil
 		}
+		// other lexing errors are the parser's to report
+		l.pos = max(l.pos, start+1)
 	}
-	return nil
 }
 
 func extractToken(input string, index int) string {
@@ -1,23 +1,26 @@
 package dsl
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestValidateFilterRejectsLegacyQualifier(t *testing.T) {
-	err := ValidateFilter(`repo:org/repo is:open`)
+	err := ValidateFilter(`repo:org/repo is:open`, nil)
 	if err == nil {
 		t.Fatalf("expected error for legacy qualifier")
 	}
 }
 
 func TestValidateFilterAllowsDslStyle(t *testing.T) {
-	err := ValidateFilter(`project = "org/repo" and state = "open"`)
+	err := ValidateFilter(`project = "org/repo" and state = "open"`, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
 func TestValidateFilterUnterminatedString(t *testing.T) {
-	err := ValidateFilter(`project = "org/repo`)
+	err := ValidateFilter(`project = "org/repo`, nil)
 	if err == nil {
 		t.Fatalf("expected unterminated string error")
 	}
@@ -31,3 +34,102 @@ func TestIsReserved(t *testing.T) {
 		t.Fatalf("did not expect 'project' to be reserved")
 	}
 }
+
+func TestValidateFilterSuggestsFieldNames(t *testing.T) {
+	err := ValidateFilter(`asignee = "me"`, nil)
+	if err == nil {
+		t.Fatalf("expected error for unknown field")
+	}
+	if !strings.Contains(err.Error(), "did you mean assignee?") {
+		t.Fatalf("expected a suggestion, got %v", err)
+	}
+}
+
+func TestCheckFilterReportsEveryTypeError(t *testing.T) {
+	errs := CheckFilter(`draft = "yes" and updated > "alice" and comments >= 2 and label > "bug" and type = "epic"`, nil)
+	want := []string{
+		`draft expects true or false, got "yes" at 0`,
+		`updated expects a date, duration or period, got "alice" at 18`,
+		`operator ">" is not allowed for label at 58`,
+		`type must be one of pr, issue, got "epic" at 76`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if !strings.HasPrefix(err.Error(), want[i]) {
+			t.Fatalf("expected error %d to start with %q, got %q", i, want[i], err.Error())
+		}
+	}
+}
+
+func TestCheckFilterRejectsTeamsOutsideUserFields(t *testing.T) {
+	errs := CheckFilter(`label in team("acme/backend")`, nil)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), `label cannot be compared with team("acme/backend") at 0`) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+}
+
+func TestCheckFilterRejectsInvalidPatterns(t *testing.T) {
+	errs := CheckFilter(`title ~ "(wip" and label contains "bug"`, nil)
+	want := []string{
+		`invalid regular expression "(wip": missing closing ) at 0`,
+		`operator "contains" is not allowed for label at 19`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if !strings.HasPrefix(err.Error(), want[i]) {
+			t.Fatalf("expected error %d to start with %q, got %q", i, want[i], err.Error())
+		}
+	}
+}
+
+func TestCheckFilterAcceptsWellTypedFilters(t *testing.T) {
+	for _, filter := range []string{
+		`draft = false and archived != true`,
+		`updated in last(7d) and created < 2025-01-01 and updated >= -14d`,
+		`created in between(2026-01-01, today) and updated in this_week`,
+		`comments > 3 and reactions in [1, 2]`,
+		`label not in ["wip"] and ci = "success" and type = "pr"`,
+		`provider in ["github"] and text = "flaky"`,
+		`review_requested in team("acme/backend") and author != team("acme/ops")`,
+		`title ~ "^\\[bot\\]" and body contains "flaky" and not title contains "wip"`,
+	} {
+		if errs := CheckFilter(filter, nil); len(errs) > 0 {
+			t.Fatalf("%s: unexpected errors %v", filter, errs)
+		}
+	}
+}
+
+func TestDiagnoseLocatesTypeErrors(t *testing.T) {
+	diagnostics := Diagnose(`state = "open" and draft = "true"`)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %+v", diagnostics)
+	}
+	got := diagnostics[0]
+	if got.Start != 19 || got.End != 33 {
+		t.Fatalf("unexpected range %d-%d", got.Start, got.End)
+	}
+	if len(got.Suggestions) != 1 || got.Suggestions[0] != "true" {
+		t.Fatalf("unexpected suggestions %v", got.Suggestions)
+	}
+}
+
+func TestCheckFilterAllowsDateTimes(t *testing.T) {
+	for _, filter := range []string{
+		`updated > 2026-01-05T09:30:00+01:00`,
+		`created < 2026-01-05T09:30Z and state = "open"`,
+	} {
+		if errs := CheckFilter(filter, nil); len(errs) > 0 {
+			t.Fatalf("CheckFilter(%q) = %v, want no errors", filter, errs)
+		}
+		if IsLegacyFilter(filter) {
+			t.Fatalf("IsLegacyFilter(%q) = true, want false", filter)
+		}
+	}
+	if !IsLegacyFilter(`updated > 2026-01-05T09:30Z is:open`) {
+		t.Fatalf("expected the qualifier after a date-time to be detected")
+	}
+}
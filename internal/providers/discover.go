@@ -0,0 +1,20 @@
+package providers
+
+import "github.com/charmbracelet/log"
+
+// DiscoverInstances returns the GitHub and GitLab hosts the user is logged
+// in to, narrowed down by the config's include and exclude patterns.
+func DiscoverInstances(include, exclude []string) []Instance {
+	instances := make([]Instance, 0)
+	if ghInstances, err := DiscoverGitHubInstances(); err == nil {
+		instances = append(instances, ghInstances...)
+	} else {
+		log.Warn("failed to discover GitHub hosts", "err", err)
+	}
+	if glInstances, err := DiscoverGitLabInstances(); err == nil {
+		instances = append(instances, glInstances...)
+	} else {
+		log.Warn("failed to discover GitLab hosts", "err", err)
+	}
+	return FilterInstances(instances, include, exclude)
+}
@@ -13,26 +13,24 @@ type Provider struct {
 	Instance providers.Instance
 }
 
-func (p Provider) FetchPullRequests(query string, limit int, pageInfo *data.PageInfo) (data.PullRequestsResponse, error) {
-	client, err := gh.NewGraphQLClient(gh.ClientOptions{
-		Host:      p.Instance.Host,
-		AuthToken: p.Instance.AuthToken,
-	})
-	if err != nil {
-		return data.PullRequestsResponse{}, err
-	}
-	return data.FetchPullRequestsWithClient(client, query, limit, pageInfo)
+func (p Provider) FetchPullRequests(
+	query string,
+	withReactions bool,
+	limit int,
+	pageInfo *data.PageInfo,
+) (data.PullRequestsResponse, error) {
+	return data.SearchPullRequests(p.clientOptions(), query, withReactions, limit, pageInfo)
 }
 
 func (p Provider) FetchIssues(query string, limit int, pageInfo *data.PageInfo) (data.IssuesResponse, error) {
-	client, err := gh.NewGraphQLClient(gh.ClientOptions{
+	return data.SearchIssues(p.clientOptions(), query, limit, pageInfo)
+}
+
+func (p Provider) clientOptions() gh.ClientOptions {
+	return gh.ClientOptions{
 		Host:      p.Instance.Host,
 		AuthToken: p.Instance.AuthToken,
-	})
-	if err != nil {
-		return data.IssuesResponse{}, err
 	}
-	return data.FetchIssuesWithClient(client, query, limit, pageInfo)
 }
 
 func (p Provider) Command(args ...string) *exec.Cmd {
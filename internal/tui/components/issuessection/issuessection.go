@@ -31,8 +31,14 @@ const SectionType = "issue"
 
 type Model struct {
 	section.BaseModel
-	Issues         []domain.Issue
-	ProviderErrors map[string]string
+	Issues          []domain.Issue
+	ProviderErrors  map[string]string
+	LocallyFiltered bool
+	// stream collects the results of a fetch from several providers
+	stream *section.ProviderStream[domain.Issue]
+	// held are the rows the last page fetched but didn't show yet, see
+	// section.ProviderStream.Rest
+	held []domain.Issue
 }
 
 func NewModel(
@@ -76,8 +82,12 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				blinkCmd := m.SetIsSearching(false)
 				return m, blinkCmd
 
+			case tea.KeyCtrlX:
+				return m, section.ExplainFilterCmd(m.SearchBar.Value(), m.Ctx.FilterMacros(), config.IssuesView, m.providersForFetch(), m.limit())
+
 			case tea.KeyEnter:
-				m.SearchValue = m.SearchBar.Value()
+				m.SearchValue = section.NormalizeFilter(m.SearchBar.Value())
+				m.SearchBar.SetValue(m.SearchValue)
 				m.SetIsSearching(false)
 				m.ResetRows()
 				return m, tea.Batch(m.FetchNextPageSectionRows()...)
@@ -129,55 +139,77 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 		}
 
 	case UpdateIssueMsg:
-		for i, currIssue := range m.Issues {
+		for _, currIssue := range m.Issues {
 			if currIssue.Key() == msg.Key || currIssue.Data.Number == msg.IssueNumber {
-				if msg.IsClosed != nil {
-					if *msg.IsClosed {
-						currIssue.Data.State = "CLOSED"
-					} else {
-						currIssue.Data.State = "OPEN"
-					}
-				}
-				if msg.Labels != nil {
-					currIssue.Data.Labels.Nodes = msg.Labels.Nodes
-				}
-				if msg.NewComment != nil {
-					currIssue.Data.Comments.Nodes = append(currIssue.Data.Comments.Nodes, *msg.NewComment)
-				}
-				if msg.AddedAssignees != nil {
-					currIssue.Data.Assignees.Nodes = addAssignees(
-						currIssue.Data.Assignees.Nodes, msg.AddedAssignees.Nodes)
-				}
-				if msg.RemovedAssignees != nil {
-					currIssue.Data.Assignees.Nodes = removeAssignees(
-						currIssue.Data.Assignees.Nodes, msg.RemovedAssignees.Nodes)
-				}
-				m.Issues[i] = currIssue
+				m.Ctx.Store.UpdateIssue(currIssue.Key(), func(issue *domain.Issue) {
+					applyUpdate(issue, msg)
+				})
+				m.SyncFromStore()
 				m.SetIsLoading(false)
-				m.Table.SetRows(m.BuildRows())
 				break
 			}
 		}
 
 	case SectionIssuesFetchedMsg:
 		if m.LastFetchTaskId == msg.TaskId {
+			issues := m.Ctx.Store.PutIssues(msg.Issues)
 			if m.PageInfo != nil {
-				m.Issues = append(m.Issues, msg.Issues...)
+				m.Issues = section.AppendPage(m.Issues, issues)
 			} else {
-				m.Issues = msg.Issues
+				m.Issues = issues
+				cmd = m.saveSnapshot(issues, msg.TotalCount, msg.ProviderErrors)
 			}
+			m.Ctx.Store.RetainIssues(m.Id, m.Issues)
+			m.IsStale = false
 			m.TotalCount = msg.TotalCount
 			m.SetIsLoading(false)
 			m.PageInfo = &msg.PageInfo
 			m.ProviderErrors = msg.ProviderErrors
+			m.LocallyFiltered = msg.LocallyFiltered
 			m.Table.SetRows(m.BuildRows())
 			m.UpdateLastUpdated(time.Now())
 			m.UpdateTotalItemsCount(m.TotalCount)
 		}
+
+	case section.SectionMsg:
+		if fetched, ok := msg.InternalMsg.(SectionIssuesProviderFetchedMsg); ok {
+			cmd = m.onProviderFetched(fetched)
+		}
+
+	case SectionIssuesRefreshedMsg:
+		if m.LastFetchTaskId == msg.TaskId && !section.RefreshesComplete(msg.Refreshes) {
+			// more changed than a refresh fetches, so fetch the rows again
+			m.ResetRows()
+			cmd = tea.Batch(m.FetchNextPageSectionRows()...)
+		} else if m.LastFetchTaskId == msg.TaskId {
+			var selected *domain.WorkItemKey
+			if row := m.GetCurrRow(); row != nil {
+				key := row.Key()
+				selected = &key
+			}
+			count := len(m.Issues)
+			m.Issues = m.Ctx.Store.PutIssues(section.MergeRefresh(m.Issues, msg.Refreshes, msg.Order))
+			m.Ctx.Store.RetainIssues(m.Id, m.Issues)
+			m.TotalCount += len(m.Issues) - count
+			m.ProviderErrors = msg.ProviderErrors
+			m.SetIsLoading(false)
+			m.Table.SetRows(m.BuildRows())
+			if selected != nil {
+				if i, ok := section.RowIndex(m.Issues, *selected); ok {
+					m.Table.SetCurrItem(i)
+				}
+			}
+			m.UpdateLastUpdated(time.Now())
+			m.UpdateTotalItemsCount(m.TotalCount)
+			cmd = m.saveSnapshot(m.Issues, m.TotalCount, msg.ProviderErrors)
+		}
 	}
 
 	search, searchCmd := m.SearchBar.Update(msg)
 	m.SearchBar = search
+	if m.IsSearchFocused() {
+		m.SearchBar.SetDiagnostics(section.DiagnoseFilter(m.Ctx, m.SearchBar.Value()))
+	}
 
 	prompt, promptCmd := m.PromptConfirmationBox.Update(msg)
 	m.PromptConfirmationBox = prompt
@@ -310,7 +342,12 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		return nil
 	}
 
-	var cmds []tea.Cmd
+	if m.stream != nil && m.PageInfo != nil {
+		// the next page is already being fetched
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.stopStream()}
 
 	startCursor := time.Now().String()
 	if m.PageInfo != nil {
@@ -331,18 +368,22 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	cmds = append(cmds, startCmd)
 
+	// with several providers, rows show as each provider answers unless the
+	// filter is invalid, which fetchCmd reports
+	instances := m.providersForFetch()
+	validFilters := !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) || dsl.ValidateFilter(m.GetFilters(), m.Ctx.FilterMacros()) == nil
 	fetchCmd := func() tea.Msg {
 		limit := m.Config.Limit
 		if limit == nil {
 			limit = &m.Ctx.Config.Defaults.IssuesLimit
 		}
 		if config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-			if err := dsl.ValidateFilter(m.GetFilters()); err != nil {
+			if err := dsl.ValidateFilter(m.GetFilters(), m.Ctx.FilterMacros()); err != nil {
 				return constants.TaskFinishedMsg{
 					SectionId:   m.Id,
 					SectionType: m.Type,
 					TaskId:      taskId,
-					Err:         err,
+					Err:         fmt.Errorf("section %q: %w", m.Config.Title, err),
 				}
 			}
 		}
@@ -377,122 +418,407 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 			}
 		}
 
-		if len(providers) == 1 {
-			query, skip, err := queryForProvider(providers[0], filters)
-			if err != nil {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Err:         err,
-				}
-			}
-			if skip {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Msg: SectionIssuesFetchedMsg{
-						Issues:         nil,
-						TotalCount:     0,
-						PageInfo:       data.PageInfo{HasNextPage: false},
-						TaskId:         taskId,
-						ProviderErrors: nil,
-					},
-				}
-			}
-			res, err := fetchIssuesForProvider(providers[0], query, *limit, m.PageInfo)
-			if err != nil {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Err:         err,
-				}
-			}
-
-			issues := make([]domain.Issue, 0, len(res.Issues))
-			for i := range res.Issues {
-				issues = append(issues, domain.NewIssueFromDataWithProvider(res.Issues[i], providers[0].ID))
+		query, err := section.QueryForProvider(providers[0], filters, m.Ctx.FilterMacros())
+		if err != nil {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      taskId,
+				Err:         err,
 			}
-
+		}
+		if query.Skip {
 			return constants.TaskFinishedMsg{
 				SectionId:   m.Id,
 				SectionType: m.Type,
 				TaskId:      taskId,
 				Msg: SectionIssuesFetchedMsg{
-					Issues:         issues,
-					TotalCount:     res.TotalCount,
-					PageInfo:       res.PageInfo,
+					Issues:         nil,
+					TotalCount:     0,
+					PageInfo:       data.PageInfo{HasNextPage: false},
 					TaskId:         taskId,
 					ProviderErrors: nil,
 				},
 			}
 		}
 
-		totalCount := 0
-		issues := make([]domain.Issue, 0, len(providers)*(*limit))
+		issues, totalCount, pageInfo, err := fetchMatchingIssues(providers[0], query, *limit, m.PageInfo)
+		if err != nil {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      taskId,
+				Err:         err,
+			}
+		}
+
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      taskId,
+			Msg: SectionIssuesFetchedMsg{
+				Issues:          issues,
+				TotalCount:      totalCount,
+				PageInfo:        pageInfo,
+				TaskId:          taskId,
+				ProviderErrors:  nil,
+				LocallyFiltered: query.Residual != nil,
+			},
+		}
+	}
+	if len(instances) > 1 && validFilters {
+		cmds = append(cmds, m.fetchFromProviders(taskId, instances)...)
+	} else {
+		cmds = append(cmds, fetchCmd)
+	}
+
+	return cmds
+}
+
+// RefreshSectionRows fetches the issues updated since the rows were fetched
+// and merges them into the rows. Sections that can't be narrowed down that
+// way are fetched again.
+func (m *Model) RefreshSectionRows() []tea.Cmd {
+	if m == nil || m.LastFetchTaskId == "" {
+		return nil
+	}
+
+	instances := m.providersForFetch()
+	latest := section.LatestUpdates(m.Issues)
+	filters := make(map[string]string, len(instances))
+	for _, provider := range instances {
+		narrowed, ok := section.RefreshFilter(m.GetFilters(), m.Ctx.FilterMacros(), latest[provider.ID])
+		if !ok {
+			filters = nil
+			break
+		}
+		filters[provider.ID] = narrowed
+	}
+	if len(filters) == 0 || m.IsStale {
+		m.ResetRows()
+		return m.FetchNextPageSectionRows()
+	}
+
+	stopCmd := m.stopStream()
+	taskId := fmt.Sprintf("refreshing_issues_%d_%s", m.Id, time.Now().String())
+	m.LastFetchTaskId = taskId
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf(`Refreshing issues for "%s"`, m.Config.Title),
+		FinishedText: fmt.Sprintf(`Issues for "%s" have been refreshed`, m.Config.Title),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+
+	limit := m.limit()
+	macros := m.Ctx.FilterMacros()
+	refs := section.RowRefs(m.Issues)
+	fetchCmd := func() tea.Msg {
+		refreshes := make(map[string]section.ProviderRefresh[domain.Issue], len(instances))
 		providerErrors := make(map[string]string)
+		var order *dsl.OrderBy
 		var mu sync.Mutex
 
 		group := errgroup.Group{}
 		group.SetLimit(section.ProviderFetchConcurrency)
-		for _, provider := range providers {
+		for _, provider := range instances {
 			provider := provider
 			group.Go(func() error {
-				query, skip, err := queryForProvider(provider, filters)
-				if err != nil {
-					mu.Lock()
-					providerErrors[provider.ID] = err.Error()
-					mu.Unlock()
-					return nil
-				}
-				if skip {
-					return nil
-				}
-				res, err := fetchIssuesForProvider(provider, query, *limit, nil)
+				refresh, query, err := refreshIssuesForProvider(
+					provider, filters[provider.ID], macros, limit, refs[provider.ID], latest[provider.ID])
+				mu.Lock()
+				defer mu.Unlock()
 				if err != nil {
-					mu.Lock()
 					providerErrors[provider.ID] = err.Error()
-					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				totalCount += res.TotalCount
-				for i := range res.Issues {
-					issues = append(issues, domain.NewIssueFromDataWithProvider(res.Issues[i], provider.ID))
+				if query.Order != nil {
+					order = query.Order
 				}
-				mu.Unlock()
+				refreshes[provider.ID] = refresh
 				return nil
 			})
 		}
 		_ = group.Wait()
-
+		var err error
+		if len(refreshes) == 0 && len(providerErrors) > 0 {
+			// the rows are kept, but the task fails so that an unreachable
+			// host is noticed
+			err = fmt.Errorf("refreshing %q failed for every provider", m.Config.Title)
+		}
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: m.Type,
 			TaskId:      taskId,
-			Msg: SectionIssuesFetchedMsg{
-				Issues:         issues,
-				TotalCount:     totalCount,
-				PageInfo:       data.PageInfo{HasNextPage: false},
+			Err:         err,
+			Msg: SectionIssuesRefreshedMsg{
+				Refreshes:      refreshes,
+				Order:          order,
 				TaskId:         taskId,
 				ProviderErrors: providerErrors,
 			},
 		}
 	}
-	cmds = append(cmds, fetchCmd)
+	m.IsLoading = true
+
+	return []tea.Cmd{stopCmd, startCmd, fetchCmd}
+}
+
+// refreshIssuesForProvider fetches the issues matching filters, already
+// narrowed down to recent updates, page by page up to RefreshMaxPages, and
+// looks up which of refs were updated after since.
+func refreshIssuesForProvider(
+	provider providers.Instance,
+	filters string,
+	macros map[string]string,
+	limit int,
+	refs []data.ItemRef,
+	since time.Time,
+) (section.ProviderRefresh[domain.Issue], section.ProviderQuery, error) {
+	query, err := section.QueryForProvider(provider, filters, macros)
+	if err != nil || query.Skip {
+		return section.ProviderRefresh[domain.Issue]{Complete: true}, query, err
+	}
+	var refresh section.ProviderRefresh[domain.Issue]
+	var pageInfo *data.PageInfo
+	for range section.RefreshMaxPages {
+		res, err := fetchIssuesForProvider(provider, query, limit, pageInfo)
+		if err != nil {
+			return section.ProviderRefresh[domain.Issue]{}, query, err
+		}
+		issues, _, err := issuesFromResponse(provider, res, query)
+		if err != nil {
+			return section.ProviderRefresh[domain.Issue]{}, query, err
+		}
+		refresh.Items = append(refresh.Items, issues...)
+		if !res.PageInfo.HasNextPage {
+			refresh.Complete = true
+			break
+		}
+		pageInfo = &res.PageInfo
+	}
+	refresh.Changed, err = data.UpdatedSince(provider, data.GitLabIssues, refs, since)
+	if err != nil {
+		return section.ProviderRefresh[domain.Issue]{}, query, err
+	}
+	return refresh, query, nil
+}
+
+// fetchFromProviders fetches the next page of each provider that has one,
+// sending what each returned as soon as it answers.
+func (m *Model) fetchFromProviders(taskId string, instances []providers.Instance) []tea.Cmd {
+	limit := m.limit()
+	filters := m.GetFilters()
+	macros := m.Ctx.FilterMacros()
+	pages := m.ProviderPages
+	fetching := make([]providers.Instance, 0, len(instances))
+	ids := make([]string, 0, len(instances))
+	for _, provider := range instances {
+		if _, ok := section.NextProviderPage(pages, provider.ID); ok {
+			fetching = append(fetching, provider)
+			ids = append(ids, provider.ID)
+		}
+	}
+	if len(fetching) == 0 {
+		return []tea.Cmd{m.nothingToFetchCmd(taskId)}
+	}
+	m.stream = section.NewProviderStream[domain.Issue](taskId, ids)
+	if m.PageInfo == nil {
+		m.stream.FirstPage = true
+	} else {
+		m.stream.Base = m.Issues
+		m.stream.Held = m.held
+	}
 
+	sem := make(chan struct{}, section.ProviderFetchConcurrency)
+	cmds := make([]tea.Cmd, 0, len(fetching))
+	for _, provider := range fetching {
+		cmds = append(cmds, m.MakeSectionCmd(func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			return SectionIssuesProviderFetchedMsg{
+				TaskId: taskId,
+				Result: fetchIssuesPage(provider, filters, macros, limit, pages),
+			}
+		}))
+	}
 	return cmds
 }
 
+func fetchIssuesPage(
+	provider providers.Instance,
+	filters string,
+	macros map[string]string,
+	limit int,
+	pages map[string]section.ProviderPage,
+) section.ProviderResult[domain.Issue] {
+	result := section.ProviderResult[domain.Issue]{ProviderID: provider.ID}
+	query, err := section.QueryForProvider(provider, filters, macros)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if query.Skip {
+		return result
+	}
+	pageInfo, _ := section.NextProviderPage(pages, provider.ID)
+	issues, totalCount, next, err := fetchMatchingIssues(provider, query, limit, pageInfo)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Items = issues
+	result.Page = &section.ProviderPage{PageInfo: next, TotalCount: totalCount}
+	result.Order = query.Order
+	result.LocallyFiltered = query.Residual != nil
+	return result
+}
+
+// nothingToFetchCmd finishes a fetch none of the providers has a page left
+// for, without adding rows.
+func (m *Model) nothingToFetchCmd(taskId string) tea.Cmd {
+	totalCount := 0
+	if m.PageInfo != nil {
+		totalCount = m.TotalCount
+	}
+	msg := SectionIssuesFetchedMsg{
+		TotalCount:      totalCount,
+		PageInfo:        data.PageInfo{HasNextPage: false},
+		TaskId:          taskId,
+		ProviderErrors:  m.ProviderErrors,
+		LocallyFiltered: m.LocallyFiltered,
+	}
+	return func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      taskId,
+			Msg:         msg,
+		}
+	}
+}
+
+// stopStream drops the provider stream still running, if any, and finishes
+// its task, since the results it would have finished it with are dropped.
+func (m *Model) stopStream() tea.Cmd {
+	if m.stream == nil {
+		return nil
+	}
+	taskId := m.stream.TaskId
+	m.stream = nil
+	return section.FinishStreamCmd(m.Id, m.Type, taskId)
+}
+
+// onProviderFetched shows what a provider returned along with what the
+// others returned so far, and finishes the fetch once all have answered.
+func (m *Model) onProviderFetched(msg SectionIssuesProviderFetchedMsg) tea.Cmd {
+	stream := m.stream
+	if stream == nil || stream.TaskId != msg.TaskId || m.LastFetchTaskId != msg.TaskId {
+		return nil
+	}
+	var selected *domain.WorkItemKey
+	if row := m.GetCurrRow(); row != nil {
+		key := row.Key()
+		selected = &key
+	}
+	stream.Add(msg.Result)
+	pages, pageInfo, totalCount := section.MergeProviderPages(m.ProviderPages, stream.Fetched)
+	if !stream.Failed() {
+		m.Issues = m.Ctx.Store.PutIssues(stream.Rows())
+		m.Ctx.Store.RetainIssues(m.Id, m.Issues)
+		m.IsStale = false
+		m.TotalCount = totalCount
+		m.LocallyFiltered = stream.LocallyFiltered
+	}
+	m.ProviderErrors = stream.Errors
+	m.Table.SetIsLoading(false)
+	m.Table.SetRows(m.BuildRows())
+	if selected != nil {
+		if i, ok := section.RowIndex(m.Issues, *selected); ok {
+			m.Table.SetCurrItem(i)
+		}
+	}
+	m.UpdateTotalItemsCount(m.TotalCount)
+	if !stream.Done() {
+		return nil
+	}
+
+	m.stream = nil
+	m.SetIsLoading(false)
+	if err := stream.Err(); err != nil {
+		return func() tea.Msg {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      msg.TaskId,
+				Err:         err,
+			}
+		}
+	}
+	m.PageInfo = &pageInfo
+	m.ProviderPages = pages
+	m.held = stream.Rest()
+	m.UpdateLastUpdated(time.Now())
+	var cmd tea.Cmd
+	if stream.FirstPage {
+		cmd = m.saveSnapshot(m.Issues, m.TotalCount, m.ProviderErrors)
+	}
+	return tea.Batch(cmd, func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      msg.TaskId,
+		}
+	})
+}
+
+func (m *Model) limit() int {
+	if m.Config.Limit != nil {
+		return *m.Config.Limit
+	}
+	return m.Ctx.Config.Defaults.IssuesLimit
+}
+
+func (m *Model) snapshotKey() string {
+	return section.SnapshotKey(SectionType, m.GetFilters(), m.Ctx.FilterMacros(), m.limit(), m.providersForFetch())
+}
+
+// restoreSnapshot shows the results cached by the last launch until the
+// section has been fetched.
+func (m *Model) restoreSnapshot() {
+	m.SnapshotKey = m.snapshotKey()
+	snapshot, ok := section.LoadSnapshot[domain.Issue](m.SnapshotKey)
+	if !ok {
+		return
+	}
+	m.Issues = m.Ctx.Store.PutIssues(snapshot.Items)
+	m.Ctx.Store.RetainIssues(m.Id, m.Issues)
+	m.TotalCount = snapshot.TotalCount
+	m.IsStale = true
+	m.Table.SetRows(m.BuildRows())
+	m.UpdateLastUpdated(snapshot.SavedAt)
+	m.UpdateTotalItemsCount(m.TotalCount)
+}
+
+// saveSnapshot caches the first page of the section's configured filters
+// once every provider answered.
+func (m *Model) saveSnapshot(issues []domain.Issue, totalCount int, providerErrors map[string]string) tea.Cmd {
+	if len(providerErrors) > 0 || m.SnapshotKey == "" || m.snapshotKey() != m.SnapshotKey {
+		return nil
+	}
+	return section.SaveSnapshotCmd(m.SnapshotKey, issues, totalCount)
+}
+
 func (m *Model) UpdateLastUpdated(t time.Time) {
 	m.Table.UpdateLastUpdated(t)
 }
 
 func (m *Model) ResetRows() {
 	m.Issues = nil
+	m.held = nil
 	m.ProviderErrors = nil
+	m.LocallyFiltered = false
 	m.BaseModel.ResetRows()
 }
 
@@ -523,81 +849,107 @@ func filterAuthenticatedProviders(instances []providers.Instance) []providers.In
 
 func fetchIssuesForProvider(
 	provider providers.Instance,
-	query string,
+	query section.ProviderQuery,
 	limit int,
 	pageInfo *data.PageInfo,
 ) (data.IssuesResponse, error) {
 	if config.IsFeatureEnabled(config.FF_MOCK_DATA) {
-		return data.FetchIssues(query, limit, pageInfo)
+		return data.FetchIssues(query.Query, limit, pageInfo)
 	}
 	switch provider.Kind {
 	case providers.KindGitHub:
-		return ghprovider.Provider{Instance: provider}.FetchIssues(query, limit, pageInfo)
+		responses, next, err := section.SearchChunks(query, pageInfo,
+			func(search string, pageInfo *data.PageInfo) (data.IssuesResponse, data.PageInfo, error) {
+				res, err := ghprovider.Provider{Instance: provider}.FetchIssues(search, limit, pageInfo)
+				return res, res.PageInfo, err
+			})
+		if err != nil {
+			return data.IssuesResponse{}, err
+		}
+		return mergeIssuesResponses(responses, next), nil
 	case providers.KindGitLab:
-		return data.FetchGitLabIssues(provider, query, limit)
+		return data.FetchGitLabIssues(provider, query.Query, limit, pageInfo)
 	default:
 		return data.IssuesResponse{}, fmt.Errorf("unsupported provider: %s", provider.Kind)
 	}
 }
 
-func queryForProvider(provider providers.Instance, filters string) (string, bool, error) {
-	if provider.Kind == providers.KindGitLab && !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-		return "", false, fmt.Errorf("gitlab requires DSL filters")
-	}
-	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-		return filters, false, nil
+// mergeIssuesResponses joins the responses of the chunks of a search. An
+// issue found by several chunks is kept once, but counted by each. One found
+// again on a later page is left out by section.AppendPage.
+func mergeIssuesResponses(responses []data.IssuesResponse, pageInfo data.PageInfo) data.IssuesResponse {
+	if len(responses) == 1 {
+		return responses[0]
 	}
-	expr, err := dsl.ParseFilter(filters)
-	if err != nil {
-		return "", false, err
+	merged := data.IssuesResponse{PageInfo: pageInfo}
+	seen := map[string]bool{}
+	for _, res := range responses {
+		merged.TotalCount += res.TotalCount
+		for _, issue := range res.Issues {
+			if !seen[issue.Url] {
+				seen[issue.Url] = true
+				merged.Issues = append(merged.Issues, issue)
+			}
+		}
 	}
-	switch provider.Kind {
-	case providers.KindGitHub:
-		translated, err := dsl.TranslateGitHub(expr, time.Now())
+	return merged
+}
+
+// fetchMatchingIssues fetches pages of provider's issues from pageInfo on
+// until limit of them match the query's residual, or
+// section.ResidualMaxPages pages were fetched, so that a locally filtered
+// section isn't left with a few rows. It returns the last page's info.
+func fetchMatchingIssues(
+	provider providers.Instance,
+	query section.ProviderQuery,
+	limit int,
+	pageInfo *data.PageInfo,
+) ([]domain.Issue, int, data.PageInfo, error) {
+	var issues []domain.Issue
+	dropped := 0
+	for page := 1; ; page++ {
+		res, err := fetchIssuesForProvider(provider, query, limit, pageInfo)
 		if err != nil {
-			return "", false, err
+			return nil, 0, data.PageInfo{}, err
 		}
-		if !providerAllowed(provider, translated.ProviderFilter) {
-			return "", true, nil
-		}
-		return translated.Query, false, nil
-	case providers.KindGitLab:
-		translated, err := dsl.TranslateGitLab(expr, time.Now())
+		matched, totalCount, err := issuesFromResponse(provider, res, query)
 		if err != nil {
-			return "", false, err
+			return nil, 0, data.PageInfo{}, err
 		}
-		if !providerAllowed(provider, translated.ProviderFilter) {
-			return "", true, nil
+		dropped += res.TotalCount - totalCount
+		issues = append(issues, matched...)
+		if query.Residual == nil || len(issues) >= limit || !res.PageInfo.HasNextPage || page == section.ResidualMaxPages {
+			if page > 1 && query.Order != nil {
+				dsl.SortSubjects(issues, *query.Order)
+			}
+			return issues, res.TotalCount - dropped, res.PageInfo, nil
 		}
-		return filters, false, nil
-	default:
-		return "", false, fmt.Errorf("unsupported provider: %s", provider.Kind)
+		pageInfo = &res.PageInfo
 	}
 }
 
-func providerAllowed(provider providers.Instance, filter dsl.ProviderFilter) bool {
-	if len(filter.Include) > 0 {
-		ok := false
-		for _, item := range filter.Include {
-			if providers.MatchesPattern(provider, item) {
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			return false
-		}
+// issuesFromResponse wraps a provider response and applies the query's
+// residual filter and order. The total count is reduced by the items
+// filtered out of this page.
+func issuesFromResponse(
+	provider providers.Instance,
+	res data.IssuesResponse,
+	query section.ProviderQuery,
+) ([]domain.Issue, int, error) {
+	issues := make([]domain.Issue, 0, len(res.Issues))
+	for i := range res.Issues {
+		issues = append(issues, domain.NewIssueFromDataWithProvider(res.Issues[i], provider.ID))
 	}
-	for _, item := range filter.Exclude {
-		if providers.MatchesPattern(provider, item) {
-			return false
-		}
+	filtered, err := section.ApplyQuery(issues, query)
+	if err != nil {
+		return nil, 0, err
 	}
-	return true
+	return filtered, res.TotalCount - (len(issues) - len(filtered)), nil
 }
 
 func FetchAllSections(
 	ctx *context.ProgramContext,
+	issues []section.Section,
 ) (sections []section.Section, fetchAllCmd tea.Cmd) {
 	sectionConfigs := ctx.Config.IssuesSections
 	providerInstances := ctx.Providers
@@ -606,6 +958,8 @@ func FetchAllSections(
 	sections = make([]section.Section, 0, len(sectionConfigs))
 
 	index := 1
+	// 0 is the search section, which is kept across reloads
+	sectionIDs := []int{0}
 	addSection := func(sectionConfig config.IssuesSectionConfig, providerID string) {
 		sectionModel := NewModel(
 			index,
@@ -618,8 +972,17 @@ func FetchAllSections(
 		if sectionConfig.Layout.CreatorIcon.Hidden != nil {
 			sectionModel.ShowAuthorIcon = !*sectionConfig.Layout.CreatorIcon.Hidden
 		}
+		if index < len(issues) && issues[index] != nil {
+			oldSection := issues[index].(*Model)
+			sectionModel.Issues = oldSection.Issues
+			sectionModel.LastFetchTaskId = oldSection.LastFetchTaskId
+			sectionModel.SnapshotKey = sectionModel.snapshotKey()
+		} else {
+			sectionModel.restoreSnapshot()
+		}
 		sections = append(sections, &sectionModel)
 		fetchIssuesCmds = append(fetchIssuesCmds, sectionModel.FetchNextPageSectionRows()...)
+		sectionIDs = append(sectionIDs, index)
 		index++
 	}
 
@@ -637,17 +1000,67 @@ func FetchAllSections(
 		}
 	}
 
+	ctx.Store.KeepIssueSections(sectionIDs)
+
 	return sections, tea.Batch(fetchIssuesCmds...)
 }
 
 type SectionIssuesFetchedMsg struct {
-	Issues         []domain.Issue
-	TotalCount     int
-	PageInfo       data.PageInfo
+	Issues          []domain.Issue
+	TotalCount      int
+	PageInfo        data.PageInfo
+	TaskId          string
+	ProviderErrors  map[string]string
+	LocallyFiltered bool
+}
+
+// SectionIssuesProviderFetchedMsg is what one provider of a section with
+// several providers returned.
+type SectionIssuesProviderFetchedMsg struct {
+	TaskId string
+	Result section.ProviderResult[domain.Issue]
+}
+
+// SectionIssuesRefreshedMsg holds what changed on each provider since the
+// section's rows were fetched.
+type SectionIssuesRefreshedMsg struct {
+	Refreshes      map[string]section.ProviderRefresh[domain.Issue]
+	Order          *dsl.OrderBy
 	TaskId         string
 	ProviderErrors map[string]string
 }
 
+// SyncFromStore shows the stored versions of the section's issues, which
+// other sections may have updated.
+func (m *Model) SyncFromStore() {
+	m.Ctx.Store.SyncIssues(m.Issues)
+	m.Table.SetRows(m.BuildRows())
+}
+
+func applyUpdate(issue *domain.Issue, msg UpdateIssueMsg) {
+	if msg.IsClosed != nil {
+		if *msg.IsClosed {
+			issue.Data.State = "CLOSED"
+		} else {
+			issue.Data.State = "OPEN"
+		}
+	}
+	if msg.Labels != nil {
+		issue.Data.Labels.Nodes = msg.Labels.Nodes
+	}
+	if msg.NewComment != nil {
+		issue.Data.Comments.Nodes = append(issue.Data.Comments.Nodes, *msg.NewComment)
+	}
+	if msg.AddedAssignees != nil {
+		issue.Data.Assignees.Nodes = addAssignees(
+			issue.Data.Assignees.Nodes, msg.AddedAssignees.Nodes)
+	}
+	if msg.RemovedAssignees != nil {
+		issue.Data.Assignees.Nodes = removeAssignees(
+			issue.Data.Assignees.Nodes, msg.RemovedAssignees.Nodes)
+	}
+}
+
 type UpdateIssueMsg struct {
 	Key              domain.WorkItemKey
 	IssueNumber      int
@@ -713,13 +1126,30 @@ func (m Model) GetPagerContent() string {
 		pagerContent = fmt.Sprintf(
 			"%v %v • %v %v/%v • Fetched %v",
 			constants.WaitingIcon,
-			m.LastUpdated().Format("01/02 15:04:05"),
+			m.lastUpdatedText(),
 			m.SingularForm,
 			m.Table.GetCurrItem()+1,
 			m.TotalCount,
 			len(m.Table.Rows),
 		)
 	}
+	if m.LocallyFiltered {
+		filtered := fmt.Sprintf("%s filtered locally", constants.FilterIcon)
+		if pagerContent != "" {
+			pagerContent = fmt.Sprintf("%s • %s", pagerContent, filtered)
+		} else {
+			pagerContent = filtered
+		}
+	}
+	if m.stream != nil {
+		if pending := section.PendingSummary(m.Ctx, m.stream.Pending); pending != "" {
+			if pagerContent != "" {
+				pagerContent = fmt.Sprintf("%s • %s", pagerContent, pending)
+			} else {
+				pagerContent = pending
+			}
+		}
+	}
 	if errSummary := m.providerErrorsSummary(); errSummary != "" {
 		if pagerContent != "" {
 			pagerContent = fmt.Sprintf("%s • %s", pagerContent, errSummary)
@@ -731,6 +1161,14 @@ func (m Model) GetPagerContent() string {
 	return pager
 }
 
+func (m Model) lastUpdatedText() string {
+	lastUpdated := m.LastUpdated().Format("01/02 15:04:05")
+	if m.IsStale {
+		return lastUpdated + " (cached)"
+	}
+	return lastUpdated
+}
+
 func (m Model) providerErrorsSummary() string {
 	if len(m.ProviderErrors) == 0 {
 		return ""
@@ -31,7 +31,7 @@ func (m *Model) reopen() tea.Cmd {
 			err = data.GitLabSetIssueState(provider, issue.Key().RepoPath, issueNumber, "reopen")
 		} else {
 			c := ghcli.CommandForItem(m.Ctx, issue, "issue", "reopen", fmt.Sprint(issueNumber), "-R", issue.GetRepoNameWithOwner())
-			err = c.Run()
+			err = ghcli.Run(c, true)
 		}
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
@@ -7,11 +7,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/issuessection"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) comment(body string) tea.Cmd {
@@ -25,29 +24,17 @@ func (m *Model) comment(body string) tea.Cmd {
 		State:        context.TaskStart,
 		Error:        nil,
 	}
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		if provider, ok := m.ctx.ProviderForItem(issue); ok && provider.Kind == providers.KindGitLab {
-			err = data.GitLabIssueComment(provider, issue.Key().RepoPath, issueNumber, body)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, issue, "issue", "comment", fmt.Sprint(issueNumber), "-R", issue.GetRepoNameWithOwner(), "-b", body)
-			err = c.Run()
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: issuessection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: issuessection.UpdateIssueMsg{
-				Key:         m.issue.Data.Key(),
-				IssueNumber: issueNumber,
-				NewComment: &data.IssueComment{
-					Author:    struct{ Login string }{Login: m.ctx.User},
-					Body:      body,
-					UpdatedAt: time.Now(),
-				},
-			},
-		}
+
+	action := journal.NewAction(journal.KindComment, issue)
+	action.Body = body
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: issuessection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, issuessection.UpdateIssueMsg{
+		Key:         m.issue.Data.Key(),
+		IssueNumber: issueNumber,
+		NewComment: &data.IssueComment{
+			Author:    struct{ Login string }{Login: m.ctx.User},
+			Body:      body,
+			UpdatedAt: time.Now(),
+		},
 	})
 }
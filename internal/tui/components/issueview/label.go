@@ -6,11 +6,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/issuessection"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) label(labels []string) tea.Cmd {
@@ -25,54 +24,22 @@ func (m *Model) label(labels []string) tea.Cmd {
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"issue",
-		"edit",
-		fmt.Sprint(issueNumber),
-		"-R",
-		issue.GetRepoNameWithOwner(),
+	currentLabels := make([]string, 0, len(issue.Data.Labels.Nodes))
+	for _, label := range issue.Data.Labels.Nodes {
+		currentLabels = append(currentLabels, label.Name)
 	}
-	labelsMap := make(map[string]bool)
+	returnedLabels := data.IssueLabels{Nodes: []data.Label{}}
 	for _, label := range labels {
-		labelsMap[label] = true
+		returnedLabels.Nodes = append(returnedLabels.Nodes, data.Label{Name: label})
 	}
 
-	for _, label := range m.issue.Data.Data.Labels.Nodes {
-		if _, ok := labelsMap[label.Name]; !ok {
-			commandArgs = append(commandArgs, "--remove-label")
-			commandArgs = append(commandArgs, label.Name)
-		}
-	}
-
-	for _, label := range labels {
-		commandArgs = append(commandArgs, "--add-label")
-		commandArgs = append(commandArgs, label)
-	}
-
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		if provider, ok := m.ctx.ProviderForItem(issue); ok && provider.Kind == providers.KindGitLab {
-			err = data.GitLabSetIssueLabels(provider, issue.Key().RepoPath, issueNumber, labels)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, issue, commandArgs...)
-			err = c.Run()
-		}
-
-		returnedLabels := data.IssueLabels{Nodes: []data.Label{}}
-		for _, label := range labels {
-			returnedLabels.Nodes = append(returnedLabels.Nodes, data.Label{Name: label})
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: issuessection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: issuessection.UpdateIssueMsg{
-				Key:         m.issue.Data.Key(),
-				IssueNumber: issueNumber,
-				Labels:      &returnedLabels,
-			},
-		}
+	action := journal.NewAction(journal.KindLabel, issue)
+	action.Values = labels
+	action.Current = currentLabels
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: issuessection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, issuessection.UpdateIssueMsg{
+		Key:         m.issue.Data.Key(),
+		IssueNumber: issueNumber,
+		Labels:      &returnedLabels,
 	})
 }
@@ -6,11 +6,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/issuessection"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) unassign(usernames []string) tea.Cmd {
@@ -25,44 +24,19 @@ func (m *Model) unassign(usernames []string) tea.Cmd {
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"issue",
-		"edit",
-		fmt.Sprint(issueNumber),
-		"-R",
-		issue.GetRepoNameWithOwner(),
-	}
-	for _, assignee := range usernames {
-		commandArgs = append(commandArgs, "--remove-assignee")
-		commandArgs = append(commandArgs, assignee)
+	assignees := m.issueAssignees()
+	returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
+	for _, assignee := range assigneesToRemove(assignees, usernames) {
+		returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
 	}
 
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		assignees := m.issueAssignees()
-		removedAssignees := assigneesToRemove(assignees, usernames)
-		if provider, ok := m.ctx.ProviderForItem(issue); ok && provider.Kind == providers.KindGitLab {
-			nextAssignees := remainingAssignees(assignees, usernames)
-			err = data.GitLabSetIssueAssignees(provider, issue.Key().RepoPath, issueNumber, nextAssignees)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, issue, commandArgs...)
-			err = c.Run()
-		}
-		returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
-		for _, assignee := range removedAssignees {
-			returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: issuessection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: issuessection.UpdateIssueMsg{
-				Key:              m.issue.Data.Key(),
-				IssueNumber:      issueNumber,
-				RemovedAssignees: &returnedAssignees,
-			},
-		}
+	action := journal.NewAction(journal.KindUnassign, issue)
+	action.Values = usernames
+	action.Current = assignees
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: issuessection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, issuessection.UpdateIssueMsg{
+		Key:              m.issue.Data.Key(),
+		IssueNumber:      issueNumber,
+		RemovedAssignees: &returnedAssignees,
 	})
 }
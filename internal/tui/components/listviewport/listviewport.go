@@ -109,6 +109,19 @@ func (m *Model) PrevItem() int {
 	return m.currId
 }
 
+// SetCurrItem moves to the item at id one item at a time, so the viewport
+// scrolls the way it does when navigating.
+func (m *Model) SetCurrItem(id int) int {
+	id = utils.Max(utils.Min(id, m.NumCurrentItems-1), 0)
+	for m.currId < id {
+		m.NextItem()
+	}
+	for m.currId > id {
+		m.PrevItem()
+	}
+	return m.currId
+}
+
 func (m *Model) FirstItem() int {
 	m.currId = 0
 	m.viewport.GotoTop()
@@ -32,8 +32,14 @@ const SectionType = "pr"
 
 type Model struct {
 	section.BaseModel
-	Prs            []domain.PullRequest
-	ProviderErrors map[string]string
+	Prs             []domain.PullRequest
+	ProviderErrors  map[string]string
+	LocallyFiltered bool
+	// stream collects the results of a fetch from several providers
+	stream *section.ProviderStream[domain.PullRequest]
+	// held are the rows the last page fetched but didn't show yet, see
+	// section.ProviderStream.Rest
+	held []domain.PullRequest
 }
 
 func NewModel(
@@ -78,8 +84,12 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 				blinkCmd := m.SetIsSearching(false)
 				return m, blinkCmd
 
+			case tea.KeyCtrlX:
+				return m, section.ExplainFilterCmd(m.SearchBar.Value(), m.Ctx.FilterMacros(), config.PRsView, m.providersForFetch(), m.limit())
+
 			case tea.KeyEnter:
-				m.SearchValue = m.SearchBar.Value()
+				m.SearchValue = section.NormalizeFilter(m.SearchBar.Value())
+				m.SearchBar.SetValue(m.SearchValue)
 				m.SetIsSearching(false)
 				m.ResetRows()
 				return m, tea.Batch(m.FetchNextPageSectionRows()...)
@@ -152,63 +162,80 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 		}
 
 	case tasks.UpdatePRMsg:
-		for i, currPr := range m.Prs {
+		for _, currPr := range m.Prs {
 			if currPr.Key() != msg.Key && currPr.Primary.Number != msg.PrNumber {
 				continue
 			}
 
-			if msg.IsClosed != nil {
-				if *msg.IsClosed {
-					currPr.Primary.State = "CLOSED"
-				} else {
-					currPr.Primary.State = "OPEN"
-				}
-			}
-			if msg.NewComment != nil {
-				currPr.Enriched.Comments.Nodes = append(
-					currPr.Enriched.Comments.Nodes, *msg.NewComment)
-			}
-			if msg.AddedAssignees != nil {
-				currPr.Primary.Assignees.Nodes = addAssignees(
-					currPr.Primary.Assignees.Nodes, msg.AddedAssignees.Nodes)
-			}
-			if msg.RemovedAssignees != nil {
-				currPr.Primary.Assignees.Nodes = removeAssignees(
-					currPr.Primary.Assignees.Nodes, msg.RemovedAssignees.Nodes)
-			}
-			if msg.ReadyForReview != nil && *msg.ReadyForReview {
-				currPr.Primary.IsDraft = false
-			}
-			if msg.IsMerged != nil && *msg.IsMerged {
-				currPr.Primary.State = "MERGED"
-				currPr.Primary.Mergeable = ""
-			}
-			m.Prs[i] = currPr
+			m.Ctx.Store.UpdatePullRequest(currPr.Key(), func(pr *domain.PullRequest) {
+				applyUpdate(pr, msg)
+			})
+			m.SyncFromStore()
 			m.SetIsLoading(false)
-			m.Table.SetRows(m.BuildRows())
 			break
 		}
 
 	case SectionPullRequestsFetchedMsg:
 		if m.LastFetchTaskId == msg.TaskId {
+			prs := m.Ctx.Store.PutPullRequests(msg.Prs)
 			if m.PageInfo != nil {
-				m.Prs = append(m.Prs, msg.Prs...)
+				m.Prs = section.AppendPage(m.Prs, prs)
 			} else {
-				m.Prs = msg.Prs
+				m.Prs = prs
+				cmd = m.saveSnapshot(prs, msg.TotalCount, msg.ProviderErrors)
 			}
+			m.Ctx.Store.RetainPullRequests(m.Id, m.Prs)
+			m.IsStale = false
 			m.TotalCount = msg.TotalCount
 			m.PageInfo = &msg.PageInfo
 			m.ProviderErrors = msg.ProviderErrors
+			m.LocallyFiltered = msg.LocallyFiltered
 			m.SetIsLoading(false)
 			m.Table.SetRows(m.BuildRows())
 			m.Table.UpdateLastUpdated(time.Now())
 			m.UpdateTotalItemsCount(m.TotalCount)
 		}
+
+	case section.SectionMsg:
+		if fetched, ok := msg.InternalMsg.(SectionPullRequestsProviderFetchedMsg); ok {
+			cmd = m.onProviderFetched(fetched)
+		}
+
+	case SectionPullRequestsRefreshedMsg:
+		if m.LastFetchTaskId == msg.TaskId && !section.RefreshesComplete(msg.Refreshes) {
+			// more changed than a refresh fetches, so fetch the rows again
+			m.ResetRows()
+			cmd = tea.Batch(m.FetchNextPageSectionRows()...)
+		} else if m.LastFetchTaskId == msg.TaskId {
+			var selected *domain.WorkItemKey
+			if row := m.GetCurrRow(); row != nil {
+				key := row.Key()
+				selected = &key
+			}
+			count := len(m.Prs)
+			m.Prs = m.Ctx.Store.PutPullRequests(section.MergeRefresh(m.Prs, msg.Refreshes, msg.Order))
+			m.Ctx.Store.RetainPullRequests(m.Id, m.Prs)
+			m.TotalCount += len(m.Prs) - count
+			m.ProviderErrors = msg.ProviderErrors
+			m.SetIsLoading(false)
+			m.Table.SetRows(m.BuildRows())
+			if selected != nil {
+				if i, ok := section.RowIndex(m.Prs, *selected); ok {
+					m.Table.SetCurrItem(i)
+				}
+			}
+			m.Table.UpdateLastUpdated(time.Now())
+			m.UpdateTotalItemsCount(m.TotalCount)
+			cmd = m.saveSnapshot(m.Prs, m.TotalCount, msg.ProviderErrors)
+		}
 	}
 
 	search, searchCmd := m.SearchBar.Update(msg)
 	m.Table.SetRows(m.BuildRows())
 	m.SearchBar = search
+	if m.IsSearchFocused() {
+		m.SearchBar.SetDiagnostics(section.DiagnoseFilter(m.Ctx, m.SearchBar.Value()))
+	}
 
 	prompt, promptCmd := m.PromptConfirmationBox.Update(msg)
 	m.PromptConfirmationBox = prompt
@@ -219,14 +246,39 @@ func (m *Model) Update(msg tea.Msg) (section.Section, tea.Cmd) {
 	return m, tea.Batch(cmd, searchCmd, promptCmd, tableCmd)
 }
 
-func (m *Model) EnrichPR(data data.EnrichedPullRequestData) {
-	for i, currPr := range m.Prs {
-		if currPr.Primary.Number != data.Number {
-			continue
-		}
+// SyncFromStore shows the stored versions of the section's pull requests,
+// which other sections may have updated.
+func (m *Model) SyncFromStore() {
+	m.Ctx.Store.SyncPullRequests(m.Prs)
+	m.Table.SetRows(m.BuildRows())
+}
 
-		m.Prs[i].IsEnriched = true
-		m.Prs[i].Enriched = data
+func applyUpdate(pr *domain.PullRequest, msg tasks.UpdatePRMsg) {
+	if msg.IsClosed != nil {
+		if *msg.IsClosed {
+			pr.Primary.State = "CLOSED"
+		} else {
+			pr.Primary.State = "OPEN"
+		}
+	}
+	if msg.NewComment != nil {
+		pr.Enriched.Comments.Nodes = append(
+			pr.Enriched.Comments.Nodes, *msg.NewComment)
+	}
+	if msg.AddedAssignees != nil {
+		pr.Primary.Assignees.Nodes = addAssignees(
+			pr.Primary.Assignees.Nodes, msg.AddedAssignees.Nodes)
+	}
+	if msg.RemovedAssignees != nil {
+		pr.Primary.Assignees.Nodes = removeAssignees(
+			pr.Primary.Assignees.Nodes, msg.RemovedAssignees.Nodes)
+	}
+	if msg.ReadyForReview != nil && *msg.ReadyForReview {
+		pr.Primary.IsDraft = false
+	}
+	if msg.IsMerged != nil && *msg.IsMerged {
+		pr.Primary.State = "MERGED"
+		pr.Primary.Mergeable = ""
 	}
 }
 
@@ -425,9 +477,26 @@ func (m *Model) NumRows() int {
 }
 
 type SectionPullRequestsFetchedMsg struct {
-	Prs            []domain.PullRequest
-	TotalCount     int
-	PageInfo       data.PageInfo
+	Prs             []domain.PullRequest
+	TotalCount      int
+	PageInfo        data.PageInfo
+	TaskId          string
+	ProviderErrors  map[string]string
+	LocallyFiltered bool
+}
+
+// SectionPullRequestsProviderFetchedMsg is what one provider of a section
+// with several providers returned.
+type SectionPullRequestsProviderFetchedMsg struct {
+	TaskId string
+	Result section.ProviderResult[domain.PullRequest]
+}
+
+// SectionPullRequestsRefreshedMsg holds what changed on each provider since
+// the section's rows were fetched.
+type SectionPullRequestsRefreshedMsg struct {
+	Refreshes      map[string]section.ProviderRefresh[domain.PullRequest]
+	Order          *dsl.OrderBy
 	TaskId         string
 	ProviderErrors map[string]string
 }
@@ -449,7 +518,12 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		return nil
 	}
 
-	var cmds []tea.Cmd
+	if m.stream != nil && m.PageInfo != nil {
+		// the next page is already being fetched
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.stopStream()}
 
 	startCursor := time.Now().String()
 	if m.PageInfo != nil {
@@ -471,6 +545,10 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 	startCmd := m.Ctx.StartTask(task)
 	cmds = append(cmds, startCmd)
 
+	// with several providers, rows show as each provider answers unless the
+	// filter is invalid, which fetchCmd reports
+	instances := m.providersForFetch()
+	validFilters := !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) || dsl.ValidateFilter(m.GetFilters(), m.Ctx.FilterMacros()) == nil
 	fetchCmd := func() tea.Msg {
 		limit := m.Config.Limit
 		if limit == nil {
@@ -478,12 +556,12 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 		}
 
 		if config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-			if err := dsl.ValidateFilter(m.GetFilters()); err != nil {
+			if err := dsl.ValidateFilter(m.GetFilters(), m.Ctx.FilterMacros()); err != nil {
 				return constants.TaskFinishedMsg{
 					SectionId:   m.Id,
 					SectionType: m.Type,
 					TaskId:      taskId,
-					Err:         err,
+					Err:         fmt.Errorf("section %q: %w", m.Config.Title, err),
 				}
 			}
 		}
@@ -517,122 +595,416 @@ func (m *Model) FetchNextPageSectionRows() []tea.Cmd {
 			}
 		}
 
-		if len(providers) == 1 {
-			query, skip, err := queryForProvider(providers[0], filters)
-			if err != nil {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Err:         err,
-				}
-			}
-			if skip {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Msg: SectionPullRequestsFetchedMsg{
-						Prs:            nil,
-						TotalCount:     0,
-						PageInfo:       data.PageInfo{HasNextPage: false},
-						TaskId:         taskId,
-						ProviderErrors: nil,
-					},
-				}
-			}
-			res, err := fetchPullRequestsForProvider(providers[0], query, *limit, m.PageInfo)
-			if err != nil {
-				return constants.TaskFinishedMsg{
-					SectionId:   m.Id,
-					SectionType: m.Type,
-					TaskId:      taskId,
-					Err:         err,
-				}
-			}
-
-			prs := make([]domain.PullRequest, 0, len(res.Prs))
-			for i := range res.Prs {
-				prs = append(prs, domain.NewPullRequestFromDataWithProvider(res.Prs[i], providers[0].ID))
+		query, err := section.QueryForProvider(providers[0], filters, m.Ctx.FilterMacros())
+		if err != nil {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      taskId,
+				Err:         err,
 			}
+		}
+		if query.Skip {
 			return constants.TaskFinishedMsg{
 				SectionId:   m.Id,
 				SectionType: m.Type,
 				TaskId:      taskId,
 				Msg: SectionPullRequestsFetchedMsg{
-					Prs:            prs,
-					TotalCount:     res.TotalCount,
-					PageInfo:       res.PageInfo,
+					Prs:            nil,
+					TotalCount:     0,
+					PageInfo:       data.PageInfo{HasNextPage: false},
 					TaskId:         taskId,
 					ProviderErrors: nil,
 				},
 			}
 		}
 
-		totalCount := 0
-		prs := make([]domain.PullRequest, 0, len(providers)*(*limit))
+		prs, totalCount, pageInfo, err := fetchMatchingPullRequests(providers[0], query, *limit, m.PageInfo)
+		if err != nil {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      taskId,
+				Err:         err,
+			}
+		}
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      taskId,
+			Msg: SectionPullRequestsFetchedMsg{
+				Prs:             prs,
+				TotalCount:      totalCount,
+				PageInfo:        pageInfo,
+				TaskId:          taskId,
+				ProviderErrors:  nil,
+				LocallyFiltered: query.Residual != nil,
+			},
+		}
+	}
+	if len(instances) > 1 && validFilters {
+		cmds = append(cmds, m.fetchFromProviders(taskId, instances)...)
+	} else {
+		cmds = append(cmds, fetchCmd)
+	}
+
+	m.IsLoading = true
+	if isFirstFetch && !m.IsStale {
+		m.SetIsLoading(true)
+		cmds = append(cmds, m.Table.StartLoadingSpinner())
+	}
+
+	return cmds
+}
+
+// RefreshSectionRows fetches the pull requests updated since the rows were
+// fetched and merges them into the rows. Sections that can't be narrowed
+// down that way are fetched again.
+func (m *Model) RefreshSectionRows() []tea.Cmd {
+	if m == nil || m.LastFetchTaskId == "" {
+		return nil
+	}
+
+	instances := m.providersForFetch()
+	latest := section.LatestUpdates(m.Prs)
+	filters := make(map[string]string, len(instances))
+	for _, provider := range instances {
+		narrowed, ok := section.RefreshFilter(m.GetFilters(), m.Ctx.FilterMacros(), latest[provider.ID])
+		if !ok {
+			filters = nil
+			break
+		}
+		filters[provider.ID] = narrowed
+	}
+	if len(filters) == 0 || m.IsStale {
+		m.ResetRows()
+		return m.FetchNextPageSectionRows()
+	}
+
+	stopCmd := m.stopStream()
+	taskId := fmt.Sprintf("refreshing_prs_%d_%s", m.Id, time.Now().String())
+	m.LastFetchTaskId = taskId
+	task := context.Task{
+		Id:           taskId,
+		StartText:    fmt.Sprintf(`Refreshing PRs for "%s"`, m.Config.Title),
+		FinishedText: fmt.Sprintf(`PRs for "%s" have been refreshed`, m.Config.Title),
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.Ctx.StartTask(task)
+
+	limit := m.limit()
+	macros := m.Ctx.FilterMacros()
+	refs := section.RowRefs(m.Prs)
+	fetchCmd := func() tea.Msg {
+		refreshes := make(map[string]section.ProviderRefresh[domain.PullRequest], len(instances))
 		providerErrors := make(map[string]string)
+		var order *dsl.OrderBy
 		var mu sync.Mutex
 
 		group := errgroup.Group{}
 		group.SetLimit(section.ProviderFetchConcurrency)
-		for _, provider := range providers {
+		for _, provider := range instances {
 			provider := provider
 			group.Go(func() error {
-				query, skip, err := queryForProvider(provider, filters)
-				if err != nil {
-					mu.Lock()
-					providerErrors[provider.ID] = err.Error()
-					mu.Unlock()
-					return nil
-				}
-				if skip {
-					return nil
-				}
-				res, err := fetchPullRequestsForProvider(provider, query, *limit, nil)
+				refresh, query, err := refreshPullRequestsForProvider(
+					provider, filters[provider.ID], macros, limit, refs[provider.ID], latest[provider.ID])
+				mu.Lock()
+				defer mu.Unlock()
 				if err != nil {
-					mu.Lock()
 					providerErrors[provider.ID] = err.Error()
-					mu.Unlock()
 					return nil
 				}
-				mu.Lock()
-				totalCount += res.TotalCount
-				for i := range res.Prs {
-					prs = append(prs, domain.NewPullRequestFromDataWithProvider(res.Prs[i], provider.ID))
+				if query.Order != nil {
+					order = query.Order
 				}
-				mu.Unlock()
+				refreshes[provider.ID] = refresh
 				return nil
 			})
 		}
 		_ = group.Wait()
+		var err error
+		if len(refreshes) == 0 && len(providerErrors) > 0 {
+			// the rows are kept, but the task fails so that an unreachable
+			// host is noticed
+			err = fmt.Errorf("refreshing %q failed for every provider", m.Config.Title)
+		}
 		return constants.TaskFinishedMsg{
 			SectionId:   m.Id,
 			SectionType: m.Type,
 			TaskId:      taskId,
-			Msg: SectionPullRequestsFetchedMsg{
-				Prs:            prs,
-				TotalCount:     totalCount,
-				PageInfo:       data.PageInfo{HasNextPage: false},
+			Err:         err,
+			Msg: SectionPullRequestsRefreshedMsg{
+				Refreshes:      refreshes,
+				Order:          order,
 				TaskId:         taskId,
 				ProviderErrors: providerErrors,
 			},
 		}
 	}
-	cmds = append(cmds, fetchCmd)
-
 	m.IsLoading = true
-	if isFirstFetch {
-		m.SetIsLoading(true)
-		cmds = append(cmds, m.Table.StartLoadingSpinner())
+
+	return []tea.Cmd{stopCmd, startCmd, fetchCmd}
+}
+
+// refreshPullRequestsForProvider fetches the pull requests matching filters,
+// already narrowed down to recent updates, page by page up to
+// RefreshMaxPages, and looks up which of refs were updated after since.
+func refreshPullRequestsForProvider(
+	provider providers.Instance,
+	filters string,
+	macros map[string]string,
+	limit int,
+	refs []data.ItemRef,
+	since time.Time,
+) (section.ProviderRefresh[domain.PullRequest], section.ProviderQuery, error) {
+	query, err := section.QueryForProvider(provider, filters, macros)
+	if err != nil || query.Skip {
+		return section.ProviderRefresh[domain.PullRequest]{Complete: true}, query, err
 	}
+	var refresh section.ProviderRefresh[domain.PullRequest]
+	var pageInfo *data.PageInfo
+	for range section.RefreshMaxPages {
+		res, err := fetchPullRequestsForProvider(provider, query, limit, pageInfo)
+		if err != nil {
+			return section.ProviderRefresh[domain.PullRequest]{}, query, err
+		}
+		prs, _, err := pullRequestsFromResponse(provider, res, query)
+		if err != nil {
+			return section.ProviderRefresh[domain.PullRequest]{}, query, err
+		}
+		refresh.Items = append(refresh.Items, prs...)
+		if !res.PageInfo.HasNextPage {
+			refresh.Complete = true
+			break
+		}
+		pageInfo = &res.PageInfo
+	}
+	refresh.Changed, err = data.UpdatedSince(provider, data.GitLabMergeRequests, refs, since)
+	if err != nil {
+		return section.ProviderRefresh[domain.PullRequest]{}, query, err
+	}
+	return refresh, query, nil
+}
 
+// fetchFromProviders fetches the next page of each provider that has one,
+// sending what each returned as soon as it answers.
+func (m *Model) fetchFromProviders(taskId string, instances []providers.Instance) []tea.Cmd {
+	limit := m.limit()
+	filters := m.GetFilters()
+	macros := m.Ctx.FilterMacros()
+	pages := m.ProviderPages
+	fetching := make([]providers.Instance, 0, len(instances))
+	ids := make([]string, 0, len(instances))
+	for _, provider := range instances {
+		if _, ok := section.NextProviderPage(pages, provider.ID); ok {
+			fetching = append(fetching, provider)
+			ids = append(ids, provider.ID)
+		}
+	}
+	if len(fetching) == 0 {
+		return []tea.Cmd{m.nothingToFetchCmd(taskId)}
+	}
+	m.stream = section.NewProviderStream[domain.PullRequest](taskId, ids)
+	if m.PageInfo == nil {
+		m.stream.FirstPage = true
+	} else {
+		m.stream.Base = m.Prs
+		m.stream.Held = m.held
+	}
+
+	sem := make(chan struct{}, section.ProviderFetchConcurrency)
+	cmds := make([]tea.Cmd, 0, len(fetching))
+	for _, provider := range fetching {
+		cmds = append(cmds, m.MakeSectionCmd(func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			return SectionPullRequestsProviderFetchedMsg{
+				TaskId: taskId,
+				Result: fetchPullRequestsPage(provider, filters, macros, limit, pages),
+			}
+		}))
+	}
 	return cmds
 }
 
+func fetchPullRequestsPage(
+	provider providers.Instance,
+	filters string,
+	macros map[string]string,
+	limit int,
+	pages map[string]section.ProviderPage,
+) section.ProviderResult[domain.PullRequest] {
+	result := section.ProviderResult[domain.PullRequest]{ProviderID: provider.ID}
+	query, err := section.QueryForProvider(provider, filters, macros)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if query.Skip {
+		return result
+	}
+	pageInfo, _ := section.NextProviderPage(pages, provider.ID)
+	prs, totalCount, next, err := fetchMatchingPullRequests(provider, query, limit, pageInfo)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Items = prs
+	result.Page = &section.ProviderPage{PageInfo: next, TotalCount: totalCount}
+	result.Order = query.Order
+	result.LocallyFiltered = query.Residual != nil
+	return result
+}
+
+// nothingToFetchCmd finishes a fetch none of the providers has a page left
+// for, without adding rows.
+func (m *Model) nothingToFetchCmd(taskId string) tea.Cmd {
+	totalCount := 0
+	if m.PageInfo != nil {
+		totalCount = m.TotalCount
+	}
+	msg := SectionPullRequestsFetchedMsg{
+		TotalCount:      totalCount,
+		PageInfo:        data.PageInfo{HasNextPage: false},
+		TaskId:          taskId,
+		ProviderErrors:  m.ProviderErrors,
+		LocallyFiltered: m.LocallyFiltered,
+	}
+	return func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      taskId,
+			Msg:         msg,
+		}
+	}
+}
+
+// stopStream drops the provider stream still running, if any, and finishes
+// its task, since the results it would have finished it with are dropped.
+func (m *Model) stopStream() tea.Cmd {
+	if m.stream == nil {
+		return nil
+	}
+	taskId := m.stream.TaskId
+	m.stream = nil
+	return section.FinishStreamCmd(m.Id, m.Type, taskId)
+}
+
+// onProviderFetched shows what a provider returned along with what the
+// others returned so far, and finishes the fetch once all have answered.
+func (m *Model) onProviderFetched(msg SectionPullRequestsProviderFetchedMsg) tea.Cmd {
+	stream := m.stream
+	if stream == nil || stream.TaskId != msg.TaskId || m.LastFetchTaskId != msg.TaskId {
+		return nil
+	}
+	var selected *domain.WorkItemKey
+	if row := m.GetCurrRow(); row != nil {
+		key := row.Key()
+		selected = &key
+	}
+	stream.Add(msg.Result)
+	pages, pageInfo, totalCount := section.MergeProviderPages(m.ProviderPages, stream.Fetched)
+	if !stream.Failed() {
+		m.Prs = m.Ctx.Store.PutPullRequests(stream.Rows())
+		m.Ctx.Store.RetainPullRequests(m.Id, m.Prs)
+		m.IsStale = false
+		m.TotalCount = totalCount
+		m.LocallyFiltered = stream.LocallyFiltered
+	}
+	m.ProviderErrors = stream.Errors
+	m.Table.SetIsLoading(false)
+	m.Table.SetRows(m.BuildRows())
+	if selected != nil {
+		if i, ok := section.RowIndex(m.Prs, *selected); ok {
+			m.Table.SetCurrItem(i)
+		}
+	}
+	m.UpdateTotalItemsCount(m.TotalCount)
+	if !stream.Done() {
+		return nil
+	}
+
+	m.stream = nil
+	m.SetIsLoading(false)
+	if err := stream.Err(); err != nil {
+		return func() tea.Msg {
+			return constants.TaskFinishedMsg{
+				SectionId:   m.Id,
+				SectionType: m.Type,
+				TaskId:      msg.TaskId,
+				Err:         err,
+			}
+		}
+	}
+	m.PageInfo = &pageInfo
+	m.ProviderPages = pages
+	m.held = stream.Rest()
+	m.Table.UpdateLastUpdated(time.Now())
+	var cmd tea.Cmd
+	if stream.FirstPage {
+		cmd = m.saveSnapshot(m.Prs, m.TotalCount, m.ProviderErrors)
+	}
+	return tea.Batch(cmd, func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   m.Id,
+			SectionType: m.Type,
+			TaskId:      msg.TaskId,
+		}
+	})
+}
+
+func (m *Model) limit() int {
+	if m.Config.Limit != nil {
+		return *m.Config.Limit
+	}
+	return m.Ctx.Config.Defaults.PrsLimit
+}
+
+func (m *Model) snapshotKey() string {
+	return section.SnapshotKey(SectionType, m.GetFilters(), m.Ctx.FilterMacros(), m.limit(), m.providersForFetch())
+}
+
+// restoreSnapshot shows the results cached by the last launch until the
+// section has been fetched.
+func (m *Model) restoreSnapshot() {
+	m.SnapshotKey = m.snapshotKey()
+	snapshot, ok := section.LoadSnapshot[domain.PullRequest](m.SnapshotKey)
+	if !ok {
+		return
+	}
+	m.Prs = m.Ctx.Store.PutPullRequests(snapshot.Items)
+	m.Ctx.Store.RetainPullRequests(m.Id, m.Prs)
+	m.TotalCount = snapshot.TotalCount
+	m.IsStale = true
+	m.Table.SetRows(m.BuildRows())
+	m.Table.UpdateLastUpdated(snapshot.SavedAt)
+	m.UpdateTotalItemsCount(m.TotalCount)
+}
+
+// saveSnapshot caches the first page of the section's configured filters
+// once every provider answered.
+func (m *Model) saveSnapshot(
+	items []domain.PullRequest,
+	totalCount int,
+	providerErrors map[string]string,
+) tea.Cmd {
+	if len(providerErrors) > 0 || m.SnapshotKey == "" || m.snapshotKey() != m.SnapshotKey {
+		return nil
+	}
+	prs := make([]domain.PullRequest, 0, len(items))
+	for _, pr := range items {
+		prs = append(prs, domain.PullRequest{KeyValue: pr.KeyValue, Primary: pr.Primary})
+	}
+	return section.SaveSnapshotCmd(m.SnapshotKey, prs, totalCount)
+}
+
 func (m *Model) ResetRows() {
 	m.Prs = nil
+	m.held = nil
 	m.ProviderErrors = nil
+	m.LocallyFiltered = false
 	m.BaseModel.ResetRows()
 }
 
@@ -663,77 +1035,139 @@ func filterAuthenticatedProviders(instances []providers.Instance) []providers.In
 
 func fetchPullRequestsForProvider(
 	provider providers.Instance,
-	query string,
+	query section.ProviderQuery,
 	limit int,
 	pageInfo *data.PageInfo,
 ) (data.PullRequestsResponse, error) {
 	if config.IsFeatureEnabled(config.FF_MOCK_DATA) {
-		return data.FetchPullRequests(query, limit, pageInfo)
+		return data.FetchPullRequests(query.Query, limit, pageInfo)
 	}
 	switch provider.Kind {
 	case providers.KindGitHub:
-		return ghprovider.Provider{Instance: provider}.FetchPullRequests(query, limit, pageInfo)
+		responses, next, err := section.SearchChunks(query, pageInfo,
+			func(search string, pageInfo *data.PageInfo) (data.PullRequestsResponse, data.PageInfo, error) {
+				res, err := ghprovider.Provider{Instance: provider}.FetchPullRequests(search, query.WithReactions(), limit, pageInfo)
+				return res, res.PageInfo, err
+			})
+		if err != nil {
+			return data.PullRequestsResponse{}, err
+		}
+		return mergePullRequestsResponses(responses, next), nil
 	case providers.KindGitLab:
-		return data.FetchGitLabMergeRequests(provider, query, limit)
+		return data.FetchGitLabMergeRequests(provider, query.Query, limit, pageInfo)
 	default:
 		return data.PullRequestsResponse{}, fmt.Errorf("unsupported provider: %s", provider.Kind)
 	}
 }
 
-func queryForProvider(provider providers.Instance, filters string) (string, bool, error) {
-	if provider.Kind == providers.KindGitLab && !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-		return "", false, fmt.Errorf("gitlab requires DSL filters")
-	}
-	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
-		return filters, false, nil
+// mergePullRequestsResponses joins the responses of the chunks of a search.
+// A pull request found by several chunks is kept once, but counted by each.
+// One found again on a later page is left out by section.AppendPage.
+func mergePullRequestsResponses(responses []data.PullRequestsResponse, pageInfo data.PageInfo) data.PullRequestsResponse {
+	if len(responses) == 1 {
+		return responses[0]
 	}
-	expr, err := dsl.ParseFilter(filters)
-	if err != nil {
-		return "", false, err
+	merged := data.PullRequestsResponse{PageInfo: pageInfo}
+	seen := map[string]bool{}
+	for _, res := range responses {
+		merged.TotalCount += res.TotalCount
+		for _, pr := range res.Prs {
+			if !seen[pr.Url] {
+				seen[pr.Url] = true
+				merged.Prs = append(merged.Prs, pr)
+			}
+		}
 	}
-	switch provider.Kind {
-	case providers.KindGitHub:
-		translated, err := dsl.TranslateGitHub(expr, time.Now())
+	return merged
+}
+
+// fetchMatchingPullRequests fetches pages of provider's pull requests from
+// pageInfo on until limit of them match the query's residual, or
+// section.ResidualMaxPages pages were fetched, so that a locally filtered
+// section isn't left with a few rows. It returns the last page's info.
+func fetchMatchingPullRequests(
+	provider providers.Instance,
+	query section.ProviderQuery,
+	limit int,
+	pageInfo *data.PageInfo,
+) ([]domain.PullRequest, int, data.PageInfo, error) {
+	var prs []domain.PullRequest
+	dropped := 0
+	for page := 1; ; page++ {
+		res, err := fetchPullRequestsForProvider(provider, query, limit, pageInfo)
 		if err != nil {
-			return "", false, err
+			return nil, 0, data.PageInfo{}, err
 		}
-		if !providerAllowed(provider, translated.ProviderFilter) {
-			return "", true, nil
-		}
-		return translated.Query, false, nil
-	case providers.KindGitLab:
-		translated, err := dsl.TranslateGitLab(expr, time.Now())
+		matched, totalCount, err := pullRequestsFromResponse(provider, res, query)
 		if err != nil {
-			return "", false, err
+			return nil, 0, data.PageInfo{}, err
 		}
-		if !providerAllowed(provider, translated.ProviderFilter) {
-			return "", true, nil
+		dropped += res.TotalCount - totalCount
+		prs = append(prs, matched...)
+		if query.Residual == nil || len(prs) >= limit || !res.PageInfo.HasNextPage || page == section.ResidualMaxPages {
+			if page > 1 && query.Order != nil {
+				dsl.SortSubjects(prs, *query.Order)
+			}
+			return prs, res.TotalCount - dropped, res.PageInfo, nil
 		}
-		return filters, false, nil
-	default:
-		return "", false, fmt.Errorf("unsupported provider: %s", provider.Kind)
+		pageInfo = &res.PageInfo
 	}
 }
 
-func providerAllowed(provider providers.Instance, filter dsl.ProviderFilter) bool {
-	if len(filter.Include) > 0 {
-		ok := false
-		for _, item := range filter.Include {
-			if providers.MatchesPattern(provider, item) {
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			return false
-		}
+// pullRequestsFromResponse wraps a provider response and applies the query's
+// residual filter and order. The total count is reduced by the items
+// filtered out of this page.
+func pullRequestsFromResponse(
+	provider providers.Instance,
+	res data.PullRequestsResponse,
+	query section.ProviderQuery,
+) ([]domain.PullRequest, int, error) {
+	prs := make([]domain.PullRequest, 0, len(res.Prs))
+	for i := range res.Prs {
+		prs = append(prs, domain.NewPullRequestFromDataWithProvider(res.Prs[i], provider.ID))
+	}
+	candidates, err := withGitLabPipelines(provider, prs, query.Residual)
+	if err != nil {
+		return nil, 0, err
+	}
+	filtered, err := section.ApplyQuery(candidates, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return filtered, res.TotalCount - (len(prs) - len(filtered)), nil
+}
+
+// withGitLabPipelines looks up the pipelines of GitLab merge requests when
+// residual filters on ci. That takes a request per merge request, so the
+// rest of residual is applied first and only the merge requests it keeps
+// are returned and looked up.
+func withGitLabPipelines(
+	provider providers.Instance,
+	prs []domain.PullRequest,
+	residual dsl.Expr,
+) ([]domain.PullRequest, error) {
+	if provider.Kind != providers.KindGitLab || !dsl.ReferencesField(residual, "ci") ||
+		config.IsFeatureEnabled(config.FF_MOCK_DATA) {
+		return prs, nil
 	}
-	for _, item := range filter.Exclude {
-		if providers.MatchesPattern(provider, item) {
-			return false
+	var rest []dsl.Expr
+	for _, part := range dsl.Conjuncts(residual) {
+		if !dsl.ReferencesField(part, "ci") {
+			rest = append(rest, part)
 		}
 	}
-	return true
+	candidates, err := section.FilterResidual(prs, dsl.Conjoin(rest))
+	if err != nil {
+		return nil, err
+	}
+	primaries := make([]*data.PullRequestData, 0, len(candidates))
+	for _, pr := range candidates {
+		primaries = append(primaries, pr.Primary)
+	}
+	if err := data.FetchGitLabPipelineStatuses(provider, primaries); err != nil {
+		return nil, err
+	}
+	return candidates, nil
 }
 
 func FetchAllSections(
@@ -747,6 +1181,8 @@ func FetchAllSections(
 	fetchPRsCmds := make([]tea.Cmd, 0, len(configs))
 
 	index := 1
+	// 0 is the search section, which is kept across reloads
+	sectionIDs := []int{0}
 	addSection := func(sectionConfig config.PrsSectionConfig, providerID string) {
 		sectionModel := NewModel(
 			index, // 0 is the search section
@@ -760,12 +1196,16 @@ func FetchAllSections(
 			oldSection := prs[index].(*Model)
 			sectionModel.Prs = oldSection.Prs
 			sectionModel.LastFetchTaskId = oldSection.LastFetchTaskId
+			sectionModel.SnapshotKey = sectionModel.snapshotKey()
+		} else {
+			sectionModel.restoreSnapshot()
 		}
 		if sectionConfig.Layout.AuthorIcon.Hidden != nil {
 			sectionModel.ShowAuthorIcon = !*sectionConfig.Layout.AuthorIcon.Hidden
 		}
 		sections = append(sections, &sectionModel)
 		fetchPRsCmds = append(fetchPRsCmds, sectionModel.FetchNextPageSectionRows()...)
+		sectionIDs = append(sectionIDs, index)
 		index++
 	}
 
@@ -783,6 +1223,8 @@ func FetchAllSections(
 		}
 	}
 
+	ctx.Store.KeepPullRequestSections(sectionIDs)
+
 	return sections, tea.Batch(fetchPRsCmds...)
 }
 
@@ -839,6 +1281,9 @@ func (m Model) GetPagerContent() string {
 	} else {
 		timeElapsed = fmt.Sprintf("~%v ago", timeElapsed)
 	}
+	if m.IsStale {
+		timeElapsed += " (cached)"
+	}
 	if m.TotalCount > 0 {
 		pagerContent = fmt.Sprintf(
 			"%v Updated %v • %v %v/%v (fetched %v)",
@@ -850,6 +1295,23 @@ func (m Model) GetPagerContent() string {
 			len(m.Table.Rows),
 		)
 	}
+	if m.LocallyFiltered {
+		filtered := fmt.Sprintf("%s filtered locally", constants.FilterIcon)
+		if pagerContent != "" {
+			pagerContent = fmt.Sprintf("%s • %s", pagerContent, filtered)
+		} else {
+			pagerContent = filtered
+		}
+	}
+	if m.stream != nil {
+		if pending := section.PendingSummary(m.Ctx, m.stream.Pending); pending != "" {
+			if pagerContent != "" {
+				pagerContent = fmt.Sprintf("%s • %s", pagerContent, pending)
+			} else {
+				pagerContent = pending
+			}
+		}
+	}
 	if errSummary := m.providerErrorsSummary(); errSummary != "" {
 		if pagerContent != "" {
 			pagerContent = fmt.Sprintf("%s • %s", pagerContent, errSummary)
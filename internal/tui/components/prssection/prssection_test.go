@@ -0,0 +1,77 @@
+package prssection
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/section"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
+)
+
+func newTestModel(t *testing.T) Model {
+	t.Helper()
+	cfg, err := config.ParseConfig(config.Location{
+		ConfigFlag: "../../../config/testdata/test-config.yml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &context.ProgramContext{
+		Config:    &cfg,
+		StartTask: func(context.Task) tea.Cmd { return nil },
+		Store:     domain.NewStore(),
+		Providers: []providers.Instance{
+			{ID: "github:github.com", Kind: providers.KindGitHub, AuthToken: "token"},
+			{ID: "gitlab:gitlab.com", Kind: providers.KindGitLab, AuthToken: "token"},
+		},
+	}
+	ctx.Theme = theme.ParseTheme(ctx.Config)
+	ctx.Styles = context.InitStyles(ctx.Theme)
+	return NewModel(1, ctx, config.PrsSectionConfig{Title: "Mine", Filters: "is:open"}, time.Now(), time.Now(), "")
+}
+
+func TestRefreshWhileStreamingFinishesTheStreamTask(t *testing.T) {
+	m := newTestModel(t)
+	m.FetchNextPageSectionRows()
+	if m.stream == nil {
+		t.Fatalf("expected the providers to be streamed")
+	}
+	streamTaskId := m.stream.TaskId
+
+	cmds := m.RefreshSectionRows()
+	if m.LastFetchTaskId == streamTaskId {
+		t.Fatalf("expected the refresh to start a task of its own")
+	}
+	finished, ok := cmds[0]().(constants.TaskFinishedMsg)
+	if !ok || finished.TaskId != streamTaskId {
+		t.Fatalf("expected the superseded stream's task to be finished, got %#v", cmds[0]())
+	}
+}
+
+func TestFetchWithNothingLeftFinishesItsTask(t *testing.T) {
+	m := newTestModel(t)
+	m.PageInfo = &data.PageInfo{HasNextPage: true}
+	m.ProviderPages = map[string]section.ProviderPage{}
+
+	cmds := m.FetchNextPageSectionRows()
+	if m.stream != nil {
+		t.Fatalf("expected no stream without pages to fetch")
+	}
+	for _, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		if finished, ok := cmd().(constants.TaskFinishedMsg); ok && finished.TaskId == m.LastFetchTaskId {
+			return
+		}
+	}
+	t.Fatalf("expected the fetch's task to be finished")
+}
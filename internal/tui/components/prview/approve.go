@@ -5,13 +5,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) approve(comment string) tea.Cmd {
@@ -26,36 +23,11 @@ func (m *Model) approve(comment string) tea.Cmd {
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"pr",
-		"review",
-		"-R",
-		pr.GetRepoNameWithOwner(),
-		fmt.Sprint(prNumber),
-		"--approve",
-	}
-	if comment != "" {
-		commandArgs = append(commandArgs, "--body", comment)
-	}
-
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		if provider, ok := m.ctx.ProviderForItem(m.pr.Data); ok && provider.Kind == providers.KindGitLab {
-			err = data.GitLabMergeRequestApprove(provider, m.pr.Data.Key().RepoPath, prNumber, comment)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, m.pr.Data, commandArgs...)
-			err = c.Run()
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				Key:      m.pr.Data.Key(),
-				PrNumber: prNumber,
-			},
-		}
+	action := journal.NewAction(journal.KindApprove, m.pr.Data)
+	action.Body = comment
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, tasks.UpdatePRMsg{
+		Key:      m.pr.Data.Key(),
+		PrNumber: prNumber,
 	})
 }
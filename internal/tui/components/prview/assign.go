@@ -6,12 +6,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) assign(usernames []string) tea.Cmd {
@@ -26,44 +24,19 @@ func (m *Model) assign(usernames []string) tea.Cmd {
 		Error:        nil,
 	}
 
-	commandArgs := []string{
-		"pr",
-		"edit",
-		fmt.Sprint(prNumber),
-		"-R",
-		pr.GetRepoNameWithOwner(),
-	}
-	for _, assignee := range usernames {
-		commandArgs = append(commandArgs, "--add-assignee")
-		commandArgs = append(commandArgs, assignee)
+	assignees := m.prAssignees()
+	returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
+	for _, assignee := range newAssignees(assignees, usernames) {
+		returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
 	}
 
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		assignees := m.prAssignees()
-		addedAssignees := newAssignees(assignees, usernames)
-		if provider, ok := m.ctx.ProviderForItem(m.pr.Data); ok && provider.Kind == providers.KindGitLab {
-			nextAssignees := append(assignees, addedAssignees...)
-			err = data.GitLabSetMergeRequestAssignees(provider, m.pr.Data.Key().RepoPath, prNumber, nextAssignees)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, m.pr.Data, commandArgs...)
-			err = c.Run()
-		}
-		returnedAssignees := data.Assignees{Nodes: []data.Assignee{}}
-		for _, assignee := range addedAssignees {
-			returnedAssignees.Nodes = append(returnedAssignees.Nodes, data.Assignee{Login: assignee})
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				Key:            m.pr.Data.Key(),
-				PrNumber:       prNumber,
-				AddedAssignees: &returnedAssignees,
-			},
-		}
+	action := journal.NewAction(journal.KindAssign, m.pr.Data)
+	action.Values = usernames
+	action.Current = assignees
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, tasks.UpdatePRMsg{
+		Key:            m.pr.Data.Key(),
+		PrNumber:       prNumber,
+		AddedAssignees: &returnedAssignees,
 	})
 }
@@ -27,21 +27,6 @@ func newAssignees(existing []string, requested []string) []string {
 	return added
 }
 
-func remainingAssignees(existing []string, remove []string) []string {
-	removeSet := make(map[string]struct{}, len(remove))
-	for _, assignee := range remove {
-		removeSet[strings.ToLower(strings.TrimSpace(assignee))] = struct{}{}
-	}
-	remaining := make([]string, 0, len(existing))
-	for _, assignee := range existing {
-		if _, ok := removeSet[strings.ToLower(strings.TrimSpace(assignee))]; ok {
-			continue
-		}
-		remaining = append(remaining, assignee)
-	}
-	return remaining
-}
-
 func assigneesToRemove(existing []string, remove []string) []string {
 	existingSet := make(map[string]struct{}, len(existing))
 	for _, assignee := range existing {
@@ -7,12 +7,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
-	"github.com/dlvhdr/gh-dash/v4/internal/providers"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 )
 
 func (m *Model) comment(body string) tea.Cmd {
@@ -26,29 +24,17 @@ func (m *Model) comment(body string) tea.Cmd {
 		State:        context.TaskStart,
 		Error:        nil,
 	}
-	startCmd := m.ctx.StartTask(task)
-	return tea.Batch(startCmd, func() tea.Msg {
-		var err error
-		if provider, ok := m.ctx.ProviderForItem(m.pr.Data); ok && provider.Kind == providers.KindGitLab {
-			err = data.GitLabMergeRequestComment(provider, m.pr.Data.Key().RepoPath, prNumber, body)
-		} else {
-			c := ghcli.CommandForItem(m.ctx, m.pr.Data, "pr", "comment", fmt.Sprint(prNumber), "-R", pr.GetRepoNameWithOwner(), "-b", body)
-			err = c.Run()
-		}
-		return constants.TaskFinishedMsg{
-			SectionId:   m.sectionId,
-			SectionType: prssection.SectionType,
-			TaskId:      taskId,
-			Err:         err,
-			Msg: tasks.UpdatePRMsg{
-				Key:      m.pr.Data.Key(),
-				PrNumber: prNumber,
-				NewComment: &data.Comment{
-					Author:    struct{ Login string }{Login: m.ctx.User},
-					Body:      body,
-					UpdatedAt: time.Now(),
-				},
-			},
-		}
+
+	action := journal.NewAction(journal.KindComment, m.pr.Data)
+	action.Body = body
+	section := tasks.SectionIdentifier{Id: m.sectionId, Type: prssection.SectionType}
+	return tasks.PerformAction(m.ctx, section, task, action, tasks.UpdatePRMsg{
+		Key:      m.pr.Data.Key(),
+		PrNumber: prNumber,
+		NewComment: &data.Comment{
+			Author:    struct{ Login string }{Login: m.ctx.User},
+			Body:      body,
+			UpdatedAt: time.Now(),
+		},
 	})
 }
@@ -0,0 +1,138 @@
+package prview
+
+import (
+	gocontext "context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
+)
+
+const (
+	// prefetchRadius is how many rows on each side of the selected one are
+	// enriched in the background.
+	prefetchRadius = 3
+	// fetches of rows further than this from the selected one are canceled
+	prefetchCancelRadius = 10
+	prefetchConcurrency  = 3
+)
+
+// prefetcher tracks the enrichment fetches in flight. It is only touched
+// from Update, except for sem which bounds the background fetches.
+type prefetcher struct {
+	sem      chan struct{}
+	nextId   int
+	inFlight map[domain.WorkItemKey]enrichFetch
+}
+
+type enrichFetch struct {
+	id         int
+	cancel     gocontext.CancelFunc
+	background bool
+}
+
+func newPrefetcher() *prefetcher {
+	return &prefetcher{
+		sem:      make(chan struct{}, prefetchConcurrency),
+		inFlight: map[domain.WorkItemKey]enrichFetch{},
+	}
+}
+
+// PrefetchRows enriches the rows around curr in the background, so the
+// sidebar is ready when the user moves to them, and cancels the fetches of
+// rows that are now far away.
+func (m *Model) PrefetchRows(rows []domain.PullRequest, curr int) tea.Cmd {
+	if m == nil || m.prefetch == nil {
+		return nil
+	}
+	near := map[domain.WorkItemKey]bool{}
+	for i := max(curr-prefetchCancelRadius, 0); i < min(curr+prefetchCancelRadius+1, len(rows)); i++ {
+		near[rows[i].Key()] = true
+	}
+	for key, fetch := range m.prefetch.inFlight {
+		if !near[key] {
+			fetch.cancel()
+			delete(m.prefetch.inFlight, key)
+		}
+	}
+
+	var cmds []tea.Cmd
+	for i := max(curr-prefetchRadius, 0); i < min(curr+prefetchRadius+1, len(rows)); i++ {
+		if i == curr {
+			continue
+		}
+		cmds = append(cmds, m.enrich(&rows[i], true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// FinishEnrich marks the fetch that sent msg as done.
+func (m *Model) FinishEnrich(msg EnrichedPrMsg) {
+	if m == nil || m.prefetch == nil {
+		return
+	}
+	if fetch, ok := m.prefetch.inFlight[msg.Key]; ok && fetch.id == msg.fetchId {
+		fetch.cancel()
+		delete(m.prefetch.inFlight, msg.Key)
+	}
+}
+
+// enrich fetches the enriched data of pr unless it has it or a fetch is
+// already in flight. Background fetches wait for one of the
+// prefetchConcurrency slots, the selected row's doesn't, so a background
+// fetch of the selected row is canceled and started again without waiting.
+func (m *Model) enrich(pr *domain.PullRequest, background bool) tea.Cmd {
+	if pr.IsEnriched || pr.Primary == nil || !m.canEnrich(pr) {
+		return nil
+	}
+	key := pr.Key()
+	if fetch, ok := m.prefetch.inFlight[key]; ok {
+		if background || !fetch.background {
+			return nil
+		}
+		fetch.cancel()
+	}
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	m.prefetch.nextId++
+	fetchId := m.prefetch.nextId
+	m.prefetch.inFlight[key] = enrichFetch{id: fetchId, cancel: cancel, background: background}
+
+	url := pr.Primary.Url
+	sectionId := m.sectionId
+	sem := m.prefetch.sem
+	return func() tea.Msg {
+		if background {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		d, err := data.FetchPullRequestWithContext(ctx, url)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return EnrichedPrMsg{
+			Id:      sectionId,
+			Type:    prssection.SectionType,
+			Key:     key,
+			Data:    d,
+			Err:     err,
+			fetchId: fetchId,
+		}
+	}
+}
+
+// canEnrich reports whether pr is on a GitHub instance, the only kind
+// enriched data is fetched from.
+func (m *Model) canEnrich(pr *domain.PullRequest) bool {
+	if m.ctx == nil {
+		return true
+	}
+	provider, ok := m.ctx.ProviderByID(pr.Key().ProviderID)
+	return !ok || provider.Kind == providers.KindGitHub
+}
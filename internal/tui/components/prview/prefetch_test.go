@@ -0,0 +1,55 @@
+package prview
+
+import (
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+func TestPrefetchRowsAroundSelection(t *testing.T) {
+	var rows []domain.PullRequest
+	for i := range 30 {
+		rows = append(rows, domain.NewPullRequestFromDataWithProvider(data.PullRequestData{
+			Number:     i + 1,
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, "github:github.com"))
+	}
+	rows[4].IsEnriched = true
+	m := Model{prefetch: newPrefetcher()}
+
+	if m.PrefetchRows(rows, 2) == nil {
+		t.Fatalf("expected background fetches")
+	}
+	for i, row := range rows[:6] {
+		_, inFlight := m.prefetch.inFlight[row.Key()]
+		if want := i != 2 && i != 4; inFlight != want {
+			t.Fatalf("expected row %d in flight to be %v", i, want)
+		}
+	}
+
+	m.PrefetchRows(rows, 20)
+	if _, ok := m.prefetch.inFlight[rows[0].Key()]; ok {
+		t.Fatalf("expected fetches far from the selection to be canceled")
+	}
+	if _, ok := m.prefetch.inFlight[rows[17].Key()]; !ok {
+		t.Fatalf("expected rows near the new selection to be fetched")
+	}
+
+	fetch := m.prefetch.inFlight[rows[17].Key()]
+	if m.enrich(&rows[17], false) == nil {
+		t.Fatalf("expected the selected row's background fetch to be promoted")
+	}
+	if promoted := m.prefetch.inFlight[rows[17].Key()]; promoted.background || promoted.id == fetch.id {
+		t.Fatalf("expected a foreground fetch to replace the background one")
+	}
+	if m.enrich(&rows[17], true) != nil {
+		t.Fatalf("expected no background fetch while the foreground one is in flight")
+	}
+
+	fetch = m.prefetch.inFlight[rows[17].Key()]
+	m.FinishEnrich(EnrichedPrMsg{Key: rows[17].Key(), fetchId: fetch.id})
+	if _, ok := m.prefetch.inFlight[rows[17].Key()]; ok {
+		t.Fatalf("expected the finished fetch to be removed")
+	}
+}
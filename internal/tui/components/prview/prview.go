@@ -17,7 +17,6 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/carousel"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/inputbox"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prrow"
-	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prssection"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/markdown"
@@ -48,6 +47,7 @@ type Model struct {
 
 	inputBox     inputbox.Model
 	capabilities *providers.Capabilities
+	prefetch     *prefetcher
 }
 
 const (
@@ -76,6 +76,7 @@ func NewModel(ctx *context.ProgramContext) Model {
 		carousel:      c,
 
 		inputBox: inputBox,
+		prefetch: newPrefetcher(),
 	}
 }
 
@@ -415,24 +416,18 @@ func (m *Model) SetRow(d *domain.PullRequest) {
 type EnrichedPrMsg struct {
 	Id   int
 	Type string
+	Key  domain.WorkItemKey
 	Data data.EnrichedPullRequestData
 	Err  error
+
+	fetchId int
 }
 
 func (m *Model) EnrichCurrRow() tea.Cmd {
-	if m == nil || m.pr == nil || m.pr.Data.IsEnriched {
+	if m == nil || m.pr == nil || m.prefetch == nil {
 		return nil
 	}
-	url := m.pr.Data.Primary.Url
-	return func() tea.Msg {
-		d, err := data.FetchPullRequest(url)
-		return EnrichedPrMsg{
-			Id:   m.sectionId,
-			Type: prssection.SectionType,
-			Data: d,
-			Err:  err,
-		}
-	}
+	return m.enrich(m.pr.Data, false)
 }
 
 func (m *Model) SetWidth(width int) {
@@ -628,7 +623,7 @@ func (m *Model) SetSummaryViewLess() {
 }
 
 func (m *Model) SetEnrichedPR(data data.EnrichedPullRequestData) {
-	if m.pr.Data.Primary.Url == data.Url {
+	if m.pr != nil && m.pr.Data.Primary != nil && m.pr.Data.Primary.Url == data.Url {
 		m.pr.Data.Enriched = data
 		m.pr.Data.IsEnriched = true
 	}
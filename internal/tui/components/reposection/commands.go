@@ -269,7 +269,7 @@ func (m *Model) fetchPRsCmd() tea.Cmd {
 		var res data.PullRequestsResponse
 		if ok && provider.Kind == providers.KindGitLab {
 			filter := fmt.Sprintf(`author = "@me" and project = "%s" and state = "open"`, ref.ProjectPath)
-			res, err = data.FetchGitLabMergeRequests(provider, filter, *limit)
+			res, err = data.FetchGitLabMergeRequests(provider, filter, *limit, nil)
 		} else {
 			res, err = data.FetchPullRequests(fmt.Sprintf("author:@me repo:%s", ref.ProjectPath), *limit, nil)
 		}
@@ -2,11 +2,13 @@ package search
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
 )
 
@@ -14,12 +16,26 @@ type Model struct {
 	ctx          *context.ProgramContext
 	initialValue string
 	textInput    textinput.Model
+	diagnostics  []dsl.Diagnostic
+	complete     func(value string, cursor int) dsl.Completion
+	popup        *completionPopup
 }
 
 type SearchOptions struct {
 	Prefix       string
 	InitialValue string
 	Placeholder  string
+	// Complete returns the completions for the word at cursor, a byte
+	// offset into value. Tab cycles through them when it is set.
+	Complete func(value string, cursor int) dsl.Completion
+}
+
+// completionPopup is the list opened by tab. value is the input before the
+// first completion was applied, so cycling replaces the same word.
+type completionPopup struct {
+	value      string
+	completion dsl.Completion
+	selected   int
 }
 
 func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
@@ -41,6 +57,7 @@ func NewModel(ctx *context.ProgramContext, opts SearchOptions) Model {
 		ctx:          ctx,
 		textInput:    ti,
 		initialValue: opts.InitialValue,
+		complete:     opts.Complete,
 	}
 }
 
@@ -51,17 +68,147 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if msg, ok := msg.(tea.KeyMsg); ok && m.complete != nil && m.textInput.Focused() {
+		switch msg.Type {
+		case tea.KeyTab:
+			m.cycleCompletion(1)
+			return m, nil
+		case tea.KeyShiftTab:
+			m.cycleCompletion(-1)
+			return m, nil
+		default:
+			m.popup = nil
+		}
+	}
+
 	m.textInput.Width = m.getInputWidth(m.ctx)
 	m.textInput, cmd = m.textInput.Update(msg)
 	return m, cmd
 }
 
 func (m Model) View(ctx *context.ProgramContext) string {
-	return lipgloss.NewStyle().
+	input := lipgloss.NewStyle().
 		Width(ctx.MainContentWidth - 4).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(m.ctx.Theme.PrimaryBorder).
 		Render(m.textInput.View())
+	switch {
+	case !m.textInput.Focused():
+		return input
+	case m.popup != nil:
+		return lipgloss.JoinVertical(lipgloss.Left, input, m.viewPopup(ctx))
+	case len(m.diagnostics) > 0:
+		return lipgloss.JoinVertical(lipgloss.Left, input, m.viewDiagnostics(ctx))
+	default:
+		return input
+	}
+}
+
+// cycleCompletion selects the next or previous completion and applies it. A
+// single completion is applied right away, so the next tab completes the
+// word after it.
+func (m *Model) cycleCompletion(step int) {
+	if m.popup == nil {
+		value := m.textInput.Value()
+		cursor := len(string([]rune(value)[:m.textInput.Position()]))
+		completion := m.complete(value, cursor)
+		if len(completion.Items) == 0 {
+			return
+		}
+		m.popup = &completionPopup{value: value, completion: completion, selected: -1}
+	}
+
+	items := m.popup.completion.Items
+	switch {
+	case m.popup.selected == -1 && step < 0:
+		m.popup.selected = len(items) - 1
+	default:
+		m.popup.selected = (m.popup.selected + step + len(items)) % len(items)
+	}
+	value, cursor := m.popup.completion.Apply(m.popup.value, items[m.popup.selected])
+	m.textInput.SetValue(value)
+	m.textInput.SetCursor(len([]rune(value[:cursor])))
+	if len(items) == 1 {
+		m.popup = nil
+	}
+}
+
+const maxPopupItems = 6
+
+// viewPopup lists the completions under the word they replace, scrolled to
+// keep the selected one in view.
+func (m Model) viewPopup(ctx *context.ProgramContext) string {
+	items := m.popup.completion.Items
+	first := max(0, min(m.popup.selected-maxPopupItems/2, len(items)-maxPopupItems))
+	last := min(len(items), first+maxPopupItems)
+
+	itemStyle := lipgloss.NewStyle().Foreground(ctx.Theme.SecondaryText).Padding(0, 1)
+	selectedStyle := itemStyle.Foreground(ctx.Theme.PrimaryText).Background(ctx.Theme.SelectedBackground)
+	width := 0
+	for _, item := range items[first:last] {
+		width = max(width, lipgloss.Width(item))
+	}
+	lines := make([]string, 0, last-first+1)
+	for i := first; i < last; i++ {
+		style := itemStyle
+		if i == m.popup.selected {
+			style = selectedStyle
+		}
+		lines = append(lines, style.Width(width+2).Render(items[i]))
+	}
+	if len(items) > maxPopupItems {
+		lines = append(lines, itemStyle.Foreground(ctx.Theme.FaintText).
+			Render(fmt.Sprintf("%d/%d", m.popup.selected+1, len(items))))
+	}
+
+	indent := 0
+	if lipgloss.Width(m.popup.value) < m.textInput.Width {
+		// 1 for the left border
+		indent = 1 + lipgloss.Width(m.textInput.Prompt) + lipgloss.Width(m.popup.value[:m.popup.completion.Start])
+	}
+	indent = max(0, min(indent, ctx.MainContentWidth-width-4))
+	return lipgloss.NewStyle().MarginLeft(indent).Render(strings.Join(lines, "\n"))
+}
+
+// viewDiagnostics marks each diagnostic's range with carets lined up under
+// the input and lists their messages. The carets are left out when the input
+// is scrolled, as the columns no longer line up.
+func (m Model) viewDiagnostics(ctx *context.ProgramContext) string {
+	errorStyle := lipgloss.NewStyle().Foreground(ctx.Theme.ErrorText)
+	faintStyle := lipgloss.NewStyle().Foreground(ctx.Theme.FaintText)
+	value := m.textInput.Value()
+	lines := make([]string, 0, len(m.diagnostics)+1)
+
+	if lipgloss.Width(value) < m.textInput.Width {
+		// 1 for the left border
+		indent := 1 + lipgloss.Width(m.textInput.Prompt)
+		carets := []rune(strings.Repeat(" ", indent+lipgloss.Width(value)+1))
+		for _, diagnostic := range m.diagnostics {
+			start := indent + lipgloss.Width(value[:min(diagnostic.Start, len(value))])
+			end := indent + lipgloss.Width(value[:min(diagnostic.End, len(value))])
+			for col := start; col < max(end, start+1); col++ {
+				carets[col] = '^'
+			}
+		}
+		lines = append(lines, errorStyle.Render(strings.TrimRight(string(carets), " ")))
+	}
+
+	for _, diagnostic := range m.diagnostics {
+		line := errorStyle.Render(diagnostic.Message)
+		switch {
+		case len(diagnostic.Suggestions) > 0:
+			line += faintStyle.Render(fmt.Sprintf(" did you mean %s?", strings.Join(diagnostic.Suggestions, " or ")))
+		case len(diagnostic.Expected) > 0 && len(diagnostic.Expected) <= 8:
+			line += faintStyle.Render(fmt.Sprintf(" expected one of %s", strings.Join(diagnostic.Expected, ", ")))
+		}
+		lines = append(lines, " "+line)
+	}
+	return lipgloss.NewStyle().MaxWidth(ctx.MainContentWidth - 2).Render(strings.Join(lines, "\n"))
+}
+
+// SetDiagnostics sets the problems shown under the input while it's focused.
+func (m *Model) SetDiagnostics(diagnostics []dsl.Diagnostic) {
+	m.diagnostics = diagnostics
 }
 
 func (m *Model) Focus() {
@@ -74,6 +221,8 @@ func (m *Model) Blur() {
 	m.textInput.TextStyle = m.textInput.TextStyle.Faint(true)
 	m.textInput.CursorStart()
 	m.textInput.Blur()
+	m.diagnostics = nil
+	m.popup = nil
 }
 
 func (m *Model) SetValue(val string) {
@@ -0,0 +1,222 @@
+package section
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+// FilterExplanation describes how a filter is parsed and what each provider
+// instance is asked for.
+type FilterExplanation struct {
+	Filter     string
+	Tree       string
+	Normalized string
+	Note       string
+	Err        error
+	Providers  []ProviderExplanation
+}
+
+type ProviderExplanation struct {
+	Provider       providers.Instance
+	ProviderFilter string
+	Request        string
+	Residual       string
+	Order          string
+	Skipped        string
+	Err            error
+}
+
+// ExplainFilter translates filters for every instance the way a section of
+// the given view fetches them. GitLab requests may look up the current user
+// and project IDs.
+func ExplainFilter(
+	filters string,
+	macros map[string]string,
+	view config.ViewType,
+	instances []providers.Instance,
+	limit int,
+) FilterExplanation {
+	explanation := FilterExplanation{Filter: filters}
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		explanation.Note = fmt.Sprintf("%s is not set, so filters are sent to GitHub as written", config.FF_DSL_VALIDATE)
+	} else {
+		expr, err := dsl.ParseFilterWithMacros(filters, macros)
+		if err != nil {
+			explanation.Err = err
+			return explanation
+		}
+		explanation.Tree = dsl.FormatTree(expr)
+		explanation.Normalized = dsl.Format(dsl.Normalize(expr))
+	}
+	if len(instances) == 0 {
+		explanation.Note = strings.TrimSpace(explanation.Note + "\nno provider instances are enabled")
+	}
+	for _, provider := range instances {
+		explanation.Providers = append(explanation.Providers, explainForProvider(filters, macros, view, provider, limit))
+	}
+	return explanation
+}
+
+// FilterExplainedMsg carries the rendered explanation of a section's search
+// bar filter.
+type FilterExplainedMsg struct {
+	Text string
+}
+
+func ExplainFilterCmd(
+	filters string,
+	macros map[string]string,
+	view config.ViewType,
+	instances []providers.Instance,
+	limit int,
+) tea.Cmd {
+	return func() tea.Msg {
+		return FilterExplainedMsg{Text: ExplainFilter(filters, macros, view, instances, limit).String()}
+	}
+}
+
+func explainForProvider(
+	filters string,
+	macros map[string]string,
+	view config.ViewType,
+	provider providers.Instance,
+	limit int,
+) ProviderExplanation {
+	explanation := ProviderExplanation{Provider: provider}
+	if provider.AuthToken == "" {
+		explanation.Skipped = "not authenticated"
+		return explanation
+	}
+	query, err := QueryForProvider(provider, filters, macros)
+	if err != nil {
+		explanation.Err = err
+		return explanation
+	}
+	explanation.ProviderFilter = describeProviderFilter(query.ProviderFilter, !query.Skip)
+	if query.Skip {
+		explanation.Skipped = "excluded by the provider filter"
+		return explanation
+	}
+
+	resource := data.GitLabMergeRequests
+	if view == config.IssuesView {
+		resource = data.GitLabIssues
+	}
+	switch provider.Kind {
+	case providers.KindGitHub:
+		requests := make([]string, 0, len(query.Chunks)+1)
+		for _, search := range query.Searches() {
+			if view == config.IssuesView {
+				requests = append(requests, "search "+data.MakeIssuesQuery(search))
+			} else {
+				requests = append(requests, "search "+data.MakePullRequestsQuery(search))
+			}
+		}
+		explanation.Request = strings.Join(requests, "\n")
+	case providers.KindGitLab:
+		request, err := data.BuildGitLabRequest(provider, query.Query, resource, limit)
+		if err != nil {
+			explanation.Err = err
+			return explanation
+		}
+		params := url.Values{}
+		for key, value := range request.Params {
+			params.Set(key, value)
+		}
+		explanation.Request = fmt.Sprintf("GET %s/api/v4%s?%s", strings.TrimSuffix(provider.Host, "/"), request.Endpoint, params.Encode())
+	}
+
+	if query.Residual != nil {
+		explanation.Residual = dsl.Format(query.Residual)
+		var subject dsl.Subject = domain.PullRequest{Primary: &data.PullRequestData{}}
+		if view == config.IssuesView {
+			// an issue without a number has no fields
+			subject = domain.Issue{Data: data.IssueData{Number: 1}}
+		}
+		explanation.Err = dsl.CheckFields(query.Residual, subject)
+	}
+	if query.Order != nil {
+		explanation.Order = query.Order.String()
+		if provider.Kind == providers.KindGitLab && !data.GitLabSortsBy(resource, *query.Order) {
+			explanation.Order += fmt.Sprintf(" (approximate: GitLab can't sort by %s, so each page is sorted on its own)", query.Order.Field)
+		}
+	}
+	return explanation
+}
+
+func describeProviderFilter(filter dsl.ProviderFilter, allowed bool) string {
+	if len(filter.Include) == 0 && len(filter.Exclude) == 0 {
+		return "none"
+	}
+	var parts []string
+	if len(filter.Include) > 0 {
+		parts = append(parts, fmt.Sprintf("include %s", strings.Join(filter.Include, ", ")))
+	}
+	if len(filter.Exclude) > 0 {
+		parts = append(parts, fmt.Sprintf("exclude %s", strings.Join(filter.Exclude, ", ")))
+	}
+	decision := "included"
+	if !allowed {
+		decision = "excluded"
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(parts, "; "), decision)
+}
+
+func (explanation FilterExplanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "filter\n  %s\n", explanation.Filter)
+	if explanation.Err != nil {
+		fmt.Fprintf(&b, "\nerror\n  %v\n", explanation.Err)
+		return b.String()
+	}
+	if explanation.Tree != "" {
+		fmt.Fprintf(&b, "\nast\n%s\n", indentLines(explanation.Tree, "  "))
+	}
+	if explanation.Normalized != "" {
+		fmt.Fprintf(&b, "\nnormalized\n  %s\n", explanation.Normalized)
+	}
+	if explanation.Note != "" {
+		fmt.Fprintf(&b, "\nnote\n%s\n", indentLines(explanation.Note, "  "))
+	}
+	for _, provider := range explanation.Providers {
+		fmt.Fprintf(&b, "\n%s\n", provider.Provider.ID)
+		if provider.Skipped != "" {
+			fmt.Fprintf(&b, "  skipped: %s\n", provider.Skipped)
+		}
+		if provider.ProviderFilter != "" {
+			fmt.Fprintf(&b, "  provider filter: %s\n", provider.ProviderFilter)
+		}
+		if provider.Request != "" {
+			for _, request := range strings.Split(provider.Request, "\n") {
+				fmt.Fprintf(&b, "  request: %s\n", request)
+			}
+		}
+		if provider.Residual != "" {
+			fmt.Fprintf(&b, "  filtered locally: %s\n", provider.Residual)
+		}
+		if provider.Order != "" {
+			fmt.Fprintf(&b, "  order: %s\n", provider.Order)
+		}
+		if provider.Err != nil {
+			fmt.Fprintf(&b, "  error: %v\n", provider.Err)
+		}
+	}
+	return b.String()
+}
+
+func indentLines(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+	return strings.Join(lines, "\n")
+}
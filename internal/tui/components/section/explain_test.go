@@ -0,0 +1,77 @@
+package section
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestExplainFilter(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	instances := []providers.Instance{
+		{ID: "github:github.com", Kind: providers.KindGitHub, Host: "github.com", AuthToken: "token"},
+		{ID: "gitlab:gitlab.com", Kind: providers.KindGitLab, Host: "gitlab.com", AuthToken: "token"},
+		{ID: "github:ghe.example.com", Kind: providers.KindGitHub, Host: "ghe.example.com"},
+	}
+
+	explanation := ExplainFilter(`provider = "github" and state = "open" order by updated asc`, nil, config.PRsView, instances, 20)
+	if explanation.Err != nil {
+		t.Fatalf("unexpected error: %v", explanation.Err)
+	}
+	if explanation.Normalized != `provider = "github" and state = "open" order by updated asc` {
+		t.Fatalf("unexpected normalized filter: %q", explanation.Normalized)
+	}
+	if len(explanation.Providers) != 3 {
+		t.Fatalf("expected 3 providers, got %d", len(explanation.Providers))
+	}
+
+	github := explanation.Providers[0]
+	if !strings.Contains(github.Request, "is:open") || !strings.Contains(github.Request, "sort:updated-asc") {
+		t.Fatalf("unexpected github request: %q", github.Request)
+	}
+	if github.ProviderFilter != "include github (included)" {
+		t.Fatalf("unexpected provider filter: %q", github.ProviderFilter)
+	}
+	if explanation.Providers[1].Skipped != "excluded by the provider filter" {
+		t.Fatalf("expected gitlab to be excluded, got %+v", explanation.Providers[1])
+	}
+	if explanation.Providers[2].Skipped != "not authenticated" {
+		t.Fatalf("expected unauthenticated instance to be skipped, got %+v", explanation.Providers[2])
+	}
+
+	text := explanation.String()
+	for _, want := range []string{"ast\n", "order by updated asc\n", "github:github.com\n", "request: search "} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected explanation to contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestExplainFilterNotesApproximateGitLabOrder(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	instances := []providers.Instance{
+		{ID: "gitlab:gitlab.com", Kind: providers.KindGitLab, Host: "gitlab.com", AuthToken: "token"},
+	}
+
+	explanation := ExplainFilter(`state = "opened" order by comments`, nil, config.IssuesView, instances, 20)
+	if !strings.Contains(explanation.Providers[0].Order, "approximate") {
+		t.Fatalf("expected the order to be noted as approximate, got %q", explanation.Providers[0].Order)
+	}
+	explanation = ExplainFilter(`state = "opened" order by reactions`, nil, config.IssuesView, instances, 20)
+	if order := explanation.Providers[0].Order; order != "order by reactions desc" {
+		t.Fatalf("expected issues to be sorted by GitLab, got %q", order)
+	}
+}
+
+func TestExplainFilterReportsParseErrors(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	explanation := ExplainFilter(`state = `, nil, config.PRsView, nil, 20)
+	if explanation.Err == nil {
+		t.Fatalf("expected a parse error")
+	}
+	if !strings.Contains(explanation.String(), "error\n") {
+		t.Fatalf("expected the error to be rendered:\n%s", explanation.String())
+	}
+}
@@ -0,0 +1,79 @@
+package section
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+// ProviderPage is how far a section with several providers has paged
+// through the results of one of them.
+type ProviderPage struct {
+	PageInfo   data.PageInfo
+	TotalCount int
+}
+
+// NextProviderPage returns the page info to fetch the next page of a
+// provider's results with, and false when the provider has no more. Before
+// the first page, when pages is nil, every provider has one.
+func NextProviderPage(pages map[string]ProviderPage, providerID string) (*data.PageInfo, bool) {
+	if pages == nil {
+		return nil, true
+	}
+	page, ok := pages[providerID]
+	if !ok || !page.PageInfo.HasNextPage {
+		return nil, false
+	}
+	pageInfo := page.PageInfo
+	return &pageInfo, true
+}
+
+// MergeProviderPages returns pages updated with the pages just fetched,
+// along with the page info and total count of the section as a whole.
+// Providers that failed keep their previous page so they are retried.
+func MergeProviderPages(
+	pages map[string]ProviderPage,
+	fetched map[string]ProviderPage,
+) (map[string]ProviderPage, data.PageInfo, int) {
+	merged := make(map[string]ProviderPage, len(pages)+len(fetched))
+	for id, page := range pages {
+		merged[id] = page
+	}
+	for id, page := range fetched {
+		merged[id] = page
+	}
+
+	var pageInfo data.PageInfo
+	totalCount := 0
+	cursors := make([]string, 0, len(merged))
+	for id, page := range merged {
+		totalCount += page.TotalCount
+		if page.PageInfo.HasNextPage {
+			pageInfo.HasNextPage = true
+		}
+		cursors = append(cursors, id+"="+page.PageInfo.EndCursor)
+	}
+	// the start cursor tells the section's fetches apart
+	slices.Sort(cursors)
+	pageInfo.StartCursor = strings.Join(cursors, ",")
+	return merged, pageInfo, totalCount
+}
+
+// AppendPage appends the items of a next page to rows, leaving out those
+// already among them. An item matching several chunks of a search can be
+// returned again on a later page.
+func AppendPage[T RefreshItem](rows []T, items []T) []T {
+	shown := make(map[domain.WorkItemKey]bool, len(rows))
+	for _, row := range rows {
+		shown[row.Key()] = true
+	}
+	for _, item := range items {
+		if !shown[item.Key()] {
+			shown[item.Key()] = true
+			rows = append(rows, item)
+		}
+	}
+	return rows
+}
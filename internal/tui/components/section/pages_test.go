@@ -0,0 +1,56 @@
+package section
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+func TestProviderPages(t *testing.T) {
+	if pageInfo, ok := NextProviderPage(nil, "github:github.com"); !ok || pageInfo != nil {
+		t.Fatalf("expected every provider to have a first page")
+	}
+
+	pages, pageInfo, total := MergeProviderPages(nil, map[string]ProviderPage{
+		"github:github.com": {PageInfo: data.PageInfo{HasNextPage: true, EndCursor: "abc"}, TotalCount: 40},
+		"gitlab:gitlab.com": {PageInfo: data.PageInfo{HasNextPage: false}, TotalCount: 5},
+	})
+	if !pageInfo.HasNextPage || total != 45 {
+		t.Fatalf("unexpected page info %+v with total %d", pageInfo, total)
+	}
+	if next, ok := NextProviderPage(pages, "github:github.com"); !ok || next.EndCursor != "abc" {
+		t.Fatalf("expected the next GitHub page after abc, got %+v", next)
+	}
+	if _, ok := NextProviderPage(pages, "gitlab:gitlab.com"); ok {
+		t.Fatalf("expected GitLab to have no more pages")
+	}
+
+	pages, next, total := MergeProviderPages(pages, map[string]ProviderPage{
+		"github:github.com": {PageInfo: data.PageInfo{HasNextPage: false, EndCursor: "def"}, TotalCount: 40},
+	})
+	if next.HasNextPage || total != 45 || next.StartCursor == pageInfo.StartCursor {
+		t.Fatalf("unexpected page info %+v with total %d", next, total)
+	}
+	if _, ok := NextProviderPage(pages, "github:github.com"); ok {
+		t.Fatalf("expected no more pages")
+	}
+}
+
+func TestAppendPageLeavesOutShownRows(t *testing.T) {
+	issue := func(number int) domain.Issue {
+		return domain.NewIssueFromDataWithProvider(data.IssueData{
+			Number:     number,
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, "github:github.com")
+	}
+	rows := AppendPage([]domain.Issue{issue(1), issue(2)}, []domain.Issue{issue(2), issue(3), issue(3)})
+	var numbers []int
+	for _, row := range rows {
+		numbers = append(numbers, row.Data.Number)
+	}
+	if !slices.Equal(numbers, []int{1, 2, 3}) {
+		t.Fatalf("expected each item once, got %v", numbers)
+	}
+}
@@ -0,0 +1,325 @@
+package section
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+// ProviderQuery is what a section sends to a single provider. Residual holds
+// the part of the filter the provider could not express; it has to be
+// evaluated against the fetched items.
+type ProviderQuery struct {
+	Query string
+	// Chunks split a GitHub search in several, see dsl.GitHubQuery.
+	Chunks         []string
+	Residual       dsl.Expr
+	Order          *dsl.OrderBy
+	ProviderFilter dsl.ProviderFilter
+	Skip           bool
+}
+
+// NormalizeFilter returns the canonical form of a DSL filter typed into the
+// search bar, or filters unchanged if it isn't one.
+func NormalizeFilter(filters string) string {
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		return filters
+	}
+	formatted, err := dsl.FormatFilter(filters)
+	if err != nil {
+		return filters
+	}
+	return formatted
+}
+
+// DiagnoseFilter returns the problems in a DSL filter typed into the search
+// bar. Filters are only checked when the DSL is enabled.
+func DiagnoseFilter(ctx *context.ProgramContext, filters string) []dsl.Diagnostic {
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		return nil
+	}
+	return dsl.DiagnoseWithMacros(filters, ctx.FilterMacros())
+}
+
+// CompleteFilter completes the DSL filter typed into the search bar with the
+// values seen in loaded sections.
+func CompleteFilter(ctx *context.ProgramContext, value string, cursor int) dsl.Completion {
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		return dsl.Completion{Start: cursor, End: cursor}
+	}
+	return dsl.Complete(value, cursor, ctx.FilterValues, ctx.FilterMacros())
+}
+
+// completedFields are the fields whose values are collected from loaded items.
+var completedFields = []string{"label", "author", "assignee", "project"}
+
+// AddFilterValues records the values items have for the completed fields in
+// seen, a set of values per field.
+func AddFilterValues[T dsl.Subject](seen map[string]map[string]bool, items []T) {
+	for _, item := range items {
+		for _, field := range completedFields {
+			value, ok := item.FilterField(field)
+			if !ok {
+				continue
+			}
+			if seen[field] == nil {
+				seen[field] = map[string]bool{}
+			}
+			switch value := value.(type) {
+			case string:
+				if value != "" {
+					seen[field][value] = true
+				}
+			case []string:
+				for _, v := range value {
+					seen[field][v] = true
+				}
+			}
+		}
+	}
+}
+
+// FilterValues turns the sets built by AddFilterValues into sorted lists and
+// adds the IDs and kinds of instances as provider values.
+func FilterValues(seen map[string]map[string]bool, instances []providers.Instance) map[string][]string {
+	values := make(map[string][]string, len(seen)+1)
+	for field, set := range seen {
+		for value := range set {
+			values[field] = append(values[field], value)
+		}
+		sort.Strings(values[field])
+	}
+	kinds := map[providers.Kind]bool{}
+	for _, instance := range instances {
+		values["provider"] = append(values["provider"], instance.ID)
+		if !kinds[instance.Kind] {
+			kinds[instance.Kind] = true
+			values["provider"] = append(values["provider"], string(instance.Kind))
+		}
+	}
+	return values
+}
+
+// QueryForProvider translates filters, expanding references to macros, into
+// the query sent to provider and the residual matched locally.
+func QueryForProvider(provider providers.Instance, filters string, macros map[string]string) (ProviderQuery, error) {
+	if provider.Kind == providers.KindGitLab && !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		return ProviderQuery{}, fmt.Errorf("gitlab requires DSL filters")
+	}
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) {
+		return ProviderQuery{Query: filters}, nil
+	}
+	expr, err := dsl.ParseFilterWithMacros(filters, macros)
+	if err != nil {
+		return ProviderQuery{}, err
+	}
+	expanded := dsl.Format(expr)
+	expr, err = data.ExpandTeams(provider, expr, false)
+	if err != nil {
+		return ProviderQuery{}, err
+	}
+
+	var query ProviderQuery
+	var providerFilter dsl.ProviderFilter
+	switch provider.Kind {
+	case providers.KindGitHub:
+		translated, err := dsl.TranslateGitHubPartial(expr, time.Now())
+		if err != nil {
+			return ProviderQuery{}, err
+		}
+		query = ProviderQuery{
+			Query:    translated.Query,
+			Chunks:   translated.Chunks,
+			Residual: translated.Residual,
+			Order:    translated.Order,
+		}
+		if len(query.Chunks) > 0 && query.Order == nil {
+			// the results of the chunks are merged into one sorted page
+			query.Order = &dsl.DefaultOrder
+		}
+		providerFilter = translated.ProviderFilter
+	case providers.KindGitLab:
+		translated, err := dsl.TranslateGitLabPartial(expr, time.Now())
+		if err != nil {
+			return ProviderQuery{}, err
+		}
+		// data.FetchGitLab* translate the filter themselves, without macros
+		query = ProviderQuery{Query: expanded, Residual: translated.Residual, Order: translated.Order}
+		providerFilter = translated.ProviderFilter
+	default:
+		return ProviderQuery{}, fmt.Errorf("unsupported provider: %s", provider.Kind)
+	}
+
+	query.ProviderFilter = providerFilter
+	if !providerAllowed(provider, providerFilter) {
+		return ProviderQuery{ProviderFilter: providerFilter, Skip: true}, nil
+	}
+	if query.Residual != nil && dsl.RequiresCurrentUser(query.Residual) {
+		username, err := data.CurrentUser(provider)
+		if err != nil {
+			return ProviderQuery{}, err
+		}
+		query.Residual = dsl.ExpandCurrentUser(query.Residual, username)
+	}
+	if query.Residual != nil && dsl.RequiresTeams(query.Residual) {
+		// a team review request GitHub couldn't search for is matched by its members
+		query.Residual, err = data.ExpandTeams(provider, query.Residual, true)
+		if err != nil {
+			return ProviderQuery{}, err
+		}
+	}
+	return query, nil
+}
+
+// WithReactions reports whether pull requests are fetched with their
+// reactions, because they are sorted by them or the residual matches them.
+func (query ProviderQuery) WithReactions() bool {
+	if query.Order != nil && query.Order.Field == dsl.SortReactions {
+		return true
+	}
+	return query.Residual != nil && dsl.ReferencesField(query.Residual, "reactions")
+}
+
+// Searches returns the search for each chunk of a GitHub query, or just its
+// query if it has none.
+func (query ProviderQuery) Searches() []string {
+	if len(query.Chunks) == 0 {
+		return []string{query.Query}
+	}
+	searches := make([]string, 0, len(query.Chunks))
+	for _, chunk := range query.Chunks {
+		searches = append(searches, strings.TrimSpace(query.Query+" "+chunk))
+	}
+	return searches
+}
+
+// SearchChunks runs search for each chunk of query that has pages left after
+// pageInfo, all at once so that they share a request, and returns their
+// results with the page info of the next page of all of them. The cursor of
+// that page info holds the cursor of every chunk.
+func SearchChunks[R any](
+	query ProviderQuery,
+	pageInfo *data.PageInfo,
+	search func(query string, pageInfo *data.PageInfo) (R, data.PageInfo, error),
+) ([]R, data.PageInfo, error) {
+	searches := query.Searches()
+	if len(searches) == 1 {
+		result, next, err := search(searches[0], pageInfo)
+		if err != nil {
+			return nil, data.PageInfo{}, err
+		}
+		return []R{result}, next, nil
+	}
+
+	cursors := make([]chunkCursor, len(searches))
+	if pageInfo != nil {
+		if err := json.Unmarshal([]byte(pageInfo.EndCursor), &cursors); err != nil || len(cursors) != len(searches) {
+			return nil, data.PageInfo{}, fmt.Errorf("invalid cursor for %d searches", len(searches))
+		}
+	}
+	results := make([]R, len(searches))
+	pages := make([]data.PageInfo, len(searches))
+	group := errgroup.Group{}
+	for i, cursor := range cursors {
+		if cursor.Done {
+			continue
+		}
+		group.Go(func() error {
+			var page *data.PageInfo
+			if cursor.After != "" {
+				page = &data.PageInfo{HasNextPage: true, EndCursor: cursor.After}
+			}
+			var err error
+			results[i], pages[i], err = search(searches[i], page)
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, data.PageInfo{}, err
+	}
+
+	var out []R
+	next := data.PageInfo{}
+	for i := range cursors {
+		if cursors[i].Done {
+			continue
+		}
+		out = append(out, results[i])
+		cursors[i] = chunkCursor{After: pages[i].EndCursor, Done: !pages[i].HasNextPage}
+		next.HasNextPage = next.HasNextPage || pages[i].HasNextPage
+	}
+	cursor, err := json.Marshal(cursors)
+	if err != nil {
+		return nil, data.PageInfo{}, err
+	}
+	next.EndCursor = string(cursor)
+	return out, next, nil
+}
+
+type chunkCursor struct {
+	After string `json:"after,omitempty"`
+	Done  bool   `json:"done,omitempty"`
+}
+
+// ApplyQuery drops the items that do not match the residual filter and sorts
+// the rest when the filter has an order clause.
+func ApplyQuery[T dsl.Subject](items []T, query ProviderQuery) ([]T, error) {
+	filtered, err := FilterResidual(items, query.Residual)
+	if err != nil {
+		return nil, err
+	}
+	if query.Order != nil {
+		dsl.SortSubjects(filtered, *query.Order)
+	}
+	return filtered, nil
+}
+
+// FilterResidual drops the items that do not match residual.
+func FilterResidual[T dsl.Subject](items []T, residual dsl.Expr) ([]T, error) {
+	if residual == nil {
+		return items, nil
+	}
+	now := time.Now()
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		matched, err := dsl.Evaluate(residual, item, now)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func providerAllowed(provider providers.Instance, filter dsl.ProviderFilter) bool {
+	if len(filter.Include) > 0 {
+		ok := false
+		for _, item := range filter.Include {
+			if providers.MatchesPattern(provider, item) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	for _, item := range filter.Exclude {
+		if providers.MatchesPattern(provider, item) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package section
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestQueryForProviderKeepsUnsupportedPredicatesAsResidual(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	gitlab := providers.Instance{ID: "gitlab:gitlab.com", Kind: providers.KindGitLab, User: "alice"}
+
+	query, err := QueryForProvider(gitlab, `state = "opened" and label != "wip" and author = "me"`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Skip {
+		t.Fatalf("expected provider to be queried")
+	}
+	if query.Residual == nil {
+		t.Fatalf("expected negated label to be filtered locally")
+	}
+
+	query, err = QueryForProvider(gitlab, `provider = "github" and state = "open"`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !query.Skip {
+		t.Fatalf("expected gitlab to be skipped")
+	}
+}
+
+func TestQueryForProviderExpandsMacros(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	gitlab := providers.Instance{ID: "gitlab:gitlab.com", Kind: providers.KindGitLab, User: "alice"}
+	macros := map[string]string{"open": `state = "opened"`}
+
+	query, err := QueryForProvider(gitlab, `@open and label = "bug"`, macros)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `state = "opened" and label = "bug"`; query.Query != want {
+		t.Fatalf("expected the request filter %q, got %q", want, query.Query)
+	}
+	if _, err := QueryForProvider(gitlab, `@open`, nil); err == nil {
+		t.Fatalf("expected an undefined macro to be reported")
+	}
+}
+
+func TestNormalizeFilter(t *testing.T) {
+	if got := NormalizeFilter(`state="open"`); got != `state="open"` {
+		t.Fatalf("expected filter to be unchanged without the DSL flag, got %q", got)
+	}
+
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	if got := NormalizeFilter(`state="open"  AND label in ["bug","ui"]`); got != `state = "open" and label in ["bug", "ui"]` {
+		t.Fatalf("unexpected normalized filter: %q", got)
+	}
+	if got := NormalizeFilter(`is:open author:@me`); got != `is:open author:@me` {
+		t.Fatalf("expected invalid filter to be unchanged, got %q", got)
+	}
+}
+
+func TestFilterValues(t *testing.T) {
+	issue := func(author string, labels ...string) domain.Issue {
+		var item domain.Issue
+		item.Data.Number = 1
+		item.Data.Author.Login = author
+		item.Data.Repository.NameWithOwner = "org/repo"
+		for _, label := range labels {
+			item.Data.Labels.Nodes = append(item.Data.Labels.Nodes, data.Label{Name: label})
+		}
+		return item
+	}
+
+	seen := map[string]map[string]bool{}
+	AddFilterValues(seen, []domain.Issue{issue("bob", "ui", "bug"), issue("alice", "bug")})
+	values := FilterValues(seen, []providers.Instance{
+		{ID: "github:github.com", Kind: providers.KindGitHub},
+		{ID: "github:ghe.example.com", Kind: providers.KindGitHub},
+	})
+
+	want := map[string][]string{
+		"label":    {"bug", "ui"},
+		"author":   {"alice", "bob"},
+		"project":  {"org/repo"},
+		"provider": {"github:github.com", "github", "github:ghe.example.com"},
+	}
+	for field, expected := range want {
+		if !reflect.DeepEqual(values[field], expected) {
+			t.Fatalf("unexpected %s values: %v", field, values[field])
+		}
+	}
+}
+
+func TestSearchChunks(t *testing.T) {
+	query := ProviderQuery{Query: "is:open", Chunks: []string{"(author:a OR author:b)", "(author:c)"}}
+	var mu sync.Mutex
+	searched := map[string]string{}
+	search := func(search string, pageInfo *data.PageInfo) (string, data.PageInfo, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		after := ""
+		if pageInfo != nil {
+			after = pageInfo.EndCursor
+		}
+		searched[search] = after
+		if search == "is:open (author:a OR author:b)" && after == "" {
+			return search, data.PageInfo{HasNextPage: true, EndCursor: "a2"}, nil
+		}
+		return search, data.PageInfo{EndCursor: "end"}, nil
+	}
+
+	results, next, err := SearchChunks(query, nil, search)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || !next.HasNextPage {
+		t.Fatalf("unexpected first page: %v %+v", results, next)
+	}
+
+	searched = map[string]string{}
+	results, next, err = SearchChunks(query, &next, search)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"is:open (author:a OR author:b)": "a2"}
+	if !reflect.DeepEqual(searched, want) || len(results) != 1 || next.HasNextPage {
+		t.Fatalf("expected only the first chunk to be searched again: %v %v %+v", searched, results, next)
+	}
+}
+
+func TestQueryForProviderFetchesReactionsToMatchThem(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	github := providers.Instance{ID: "github:github.com", Kind: providers.KindGitHub}
+
+	query, err := QueryForProvider(github, `author = "alice" and reactions > 3`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Residual == nil || !query.WithReactions() {
+		t.Fatalf("expected reactions to be fetched for the residual %v", query.Residual)
+	}
+
+	query, err = QueryForProvider(github, `author = "alice"`, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.WithReactions() {
+		t.Fatalf("expected pull requests without reactions")
+	}
+}
@@ -0,0 +1,154 @@
+package section
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+)
+
+// Refresher is implemented by sections that can update their rows with what
+// changed since they were fetched instead of fetching them again.
+type Refresher interface {
+	RefreshSectionRows() []tea.Cmd
+}
+
+// RefreshItem is an item a section can refresh.
+type RefreshItem interface {
+	domain.WorkItem
+	dsl.Subject
+}
+
+// ResidualMaxPages is how many pages a fetch goes through for a locally
+// filtered section to fill a page of matching rows.
+const ResidualMaxPages = 5
+
+// RefreshMaxPages is how many pages of changes a refresh fetches from a
+// provider before giving up and fetching the section again.
+const RefreshMaxPages = 5
+
+// ProviderRefresh is what a refresh found on one provider: the items that
+// match the filter and were updated since the last fetch, and which of the
+// rows from the provider were updated at all. Complete is set when Items
+// holds every item matching the filter, not only the first pages of them.
+type ProviderRefresh[T RefreshItem] struct {
+	Items    []T
+	Changed  map[data.ItemRef]bool
+	Complete bool
+}
+
+// RefreshesComplete reports whether every refresh found all the changed
+// items, so the rows can be merged with them.
+func RefreshesComplete[T RefreshItem](refreshes map[string]ProviderRefresh[T]) bool {
+	for _, refresh := range refreshes {
+		if !refresh.Complete {
+			return false
+		}
+	}
+	return true
+}
+
+// RefreshFilter narrows filters, which may reference macros, to the items
+// updated after since. It returns false when the section has to be fetched
+// again instead, because only DSL filters can be narrowed, some match other
+// items without them being updated (see dsl.UpdatedAfter) and mocked data
+// can't be asked what changed.
+func RefreshFilter(filters string, macros map[string]string, since time.Time) (string, bool) {
+	if !config.IsFeatureEnabled(config.FF_DSL_VALIDATE) || config.IsFeatureEnabled(config.FF_MOCK_DATA) {
+		return "", false
+	}
+	expr, err := dsl.ParseFilterWithMacros(filters, macros)
+	if err != nil {
+		return "", false
+	}
+	narrowed, ok := dsl.UpdatedAfter(expr, since)
+	if !ok {
+		return "", false
+	}
+	if since.IsZero() {
+		// nothing was found before, so everything is new
+		return filters, true
+	}
+	return dsl.Format(narrowed), true
+}
+
+// LatestUpdates returns the time the most recently updated row of each
+// provider was updated, truncated to what providers compare.
+func LatestUpdates[T RefreshItem](rows []T) map[string]time.Time {
+	latest := map[string]time.Time{}
+	for _, row := range rows {
+		providerID := row.Key().ProviderID
+		updatedAt := row.GetUpdatedAt().Truncate(time.Second)
+		if updatedAt.After(latest[providerID]) {
+			latest[providerID] = updatedAt
+		}
+	}
+	return latest
+}
+
+// RowRefs groups the repository and number of each row by provider.
+func RowRefs[T RefreshItem](rows []T) map[string][]data.ItemRef {
+	refs := map[string][]data.ItemRef{}
+	for _, row := range rows {
+		key := row.Key()
+		refs[key.ProviderID] = append(refs[key.ProviderID], data.ItemRef{Repo: key.RepoPath, Number: key.Number})
+	}
+	return refs
+}
+
+// MergeRefresh applies refreshes to rows. Updated rows are replaced in
+// place, changed rows that no longer match are dropped and new items are put
+// first, newest first, unless order sorts them. Rows of providers without a
+// refresh are kept as they are, and so are changed rows when the refresh
+// isn't complete, since they may only be missing from the pages fetched.
+func MergeRefresh[T RefreshItem](rows []T, refreshes map[string]ProviderRefresh[T], order *dsl.OrderBy) []T {
+	fresh := map[domain.WorkItemKey]T{}
+	for _, refresh := range refreshes {
+		for _, item := range refresh.Items {
+			fresh[item.Key()] = item
+		}
+	}
+
+	merged := make([]T, 0, len(rows)+len(fresh))
+	for _, row := range rows {
+		key := row.Key()
+		if item, ok := fresh[key]; ok {
+			merged = append(merged, item)
+			delete(fresh, key)
+			continue
+		}
+		refresh, ok := refreshes[key.ProviderID]
+		if ok && refresh.Complete && refresh.Changed[data.ItemRef{Repo: key.RepoPath, Number: key.Number}] {
+			continue
+		}
+		merged = append(merged, row)
+	}
+
+	added := make([]T, 0, len(fresh))
+	for _, item := range fresh {
+		added = append(added, item)
+	}
+	slices.SortStableFunc(added, func(a, b T) int {
+		return b.GetUpdatedAt().Compare(a.GetUpdatedAt())
+	})
+	merged = append(added, merged...)
+	if order != nil {
+		dsl.SortSubjects(merged, *order)
+	}
+	return merged
+}
+
+// RowIndex returns the index of the row with key, if there is one.
+func RowIndex[T RefreshItem](rows []T, key domain.WorkItemKey) (int, bool) {
+	for i, row := range rows {
+		if row.Key() == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,94 @@
+package section
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+)
+
+func TestRefreshFilter(t *testing.T) {
+	t.Setenv(config.FF_DSL_VALIDATE, "1")
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	filters, ok := RefreshFilter(`state = "open" order by comments desc`, nil, since)
+	if !ok {
+		t.Fatalf("expected the filter to be narrowed")
+	}
+	if filters != `state = "open" and updated > 2026-05-01T12:00:00Z order by comments desc` {
+		t.Fatalf("unexpected filter: %q", filters)
+	}
+	if filters, ok := RefreshFilter(`state = "open"`, nil, time.Time{}); !ok || filters != `state = "open"` {
+		t.Fatalf("expected the whole filter without earlier results, got %q", filters)
+	}
+	if _, ok := RefreshFilter(`updated > last(7d)`, nil, since); ok {
+		t.Fatalf("expected filters on dates to be fetched again")
+	}
+}
+
+func TestMergeRefresh(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(providerID string, number int, title string, updated time.Duration) domain.Issue {
+		return domain.NewIssueFromDataWithProvider(data.IssueData{
+			Number:     number,
+			Title:      title,
+			UpdatedAt:  base.Add(updated),
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, providerID)
+	}
+	rows := []domain.Issue{
+		issue("github:github.com", 1, "first", 0),
+		issue("github:github.com", 2, "closed since", -time.Hour),
+		issue("github:github.com", 3, "edited", -2*time.Hour),
+		issue("gitlab:gitlab.com", 4, "untouched", -3*time.Hour),
+	}
+	if latest := LatestUpdates(rows); !latest["github:github.com"].Equal(base) || !latest["gitlab:gitlab.com"].Equal(base.Add(-3*time.Hour)) {
+		t.Fatalf("unexpected latest updates: %v", latest)
+	}
+
+	merged := MergeRefresh(rows, map[string]ProviderRefresh[domain.Issue]{
+		"github:github.com": {
+			Items: []domain.Issue{
+				issue("github:github.com", 3, "edited again", time.Minute),
+				issue("github:github.com", 5, "new", 2*time.Minute),
+			},
+			Changed: map[data.ItemRef]bool{
+				{Repo: "acme/app", Number: 2}: true,
+				{Repo: "acme/app", Number: 3}: true,
+			},
+			Complete: true,
+		},
+	}, nil)
+
+	var titles []string
+	for _, item := range merged {
+		titles = append(titles, item.Data.Title)
+	}
+	want := []string{"new", "first", "edited again", "untouched"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Fatalf("expected %v, got %v", want, titles)
+	}
+
+	partial := map[string]ProviderRefresh[domain.Issue]{
+		"github:github.com": {Changed: map[data.ItemRef]bool{{Repo: "acme/app", Number: 2}: true}},
+	}
+	if RefreshesComplete(partial) {
+		t.Fatalf("expected a refresh without every page not to be complete")
+	}
+	if kept := MergeRefresh(rows, partial, nil); len(kept) != len(rows) {
+		t.Fatalf("expected an incomplete refresh to keep the changed rows, got %d rows", len(kept))
+	}
+
+	sorted := MergeRefresh(rows, map[string]ProviderRefresh[domain.Issue]{},
+		&dsl.OrderBy{Field: dsl.SortUpdated, Direction: dsl.SortAsc})
+	if sorted[0].Data.Number != 4 {
+		t.Fatalf("expected the order clause to sort the rows, got %d first", sorted[0].Data.Number)
+	}
+	if i, ok := RowIndex(merged, rows[0].Key()); !ok || i != 1 {
+		t.Fatalf("expected the first row to move to 1, got %d", i)
+	}
+}
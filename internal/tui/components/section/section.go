@@ -18,6 +18,7 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/config"
 	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/common"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/prompt"
@@ -52,6 +53,13 @@ type BaseModel struct {
 	ShowAuthorIcon            bool
 	IsFilteredByCurrentRemote bool
 	IsLoading                 bool
+	// SnapshotKey is where the section's results are cached on disk, and
+	// IsStale is set while it shows a cached snapshot.
+	SnapshotKey string
+	IsStale     bool
+	// ProviderPages is how far each provider's results have been fetched
+	// when the section has several providers.
+	ProviderPages map[string]ProviderPage
 }
 
 type NewSectionOptions struct {
@@ -105,6 +113,9 @@ func NewModel(
 		SearchBar: search.NewModel(ctx, search.SearchOptions{
 			Prefix:       fmt.Sprintf("is:%s", options.Type),
 			InitialValue: filters,
+			Complete: func(value string, cursor int) dsl.Completion {
+				return CompleteFilter(ctx, value, cursor)
+			},
 		}),
 		SearchValue:               filters,
 		IsSearching:               false,
@@ -347,6 +358,7 @@ func (m *BaseModel) ResetFilters() {
 
 func (m *BaseModel) ResetPageInfo() {
 	m.PageInfo = nil
+	m.ProviderPages = nil
 }
 
 func (m *BaseModel) IsPromptConfirmationFocused() bool {
@@ -424,11 +436,16 @@ func (m *BaseModel) GetMainContent() string {
 
 func (m *BaseModel) View() string {
 	search := m.SearchBar.View(m.Ctx)
+	content := m.GetMainContent()
+	// diagnostics under the search bar take their room from the content
+	if extra := lipgloss.Height(search) - common.SearchHeight; extra > 0 {
+		content = lipgloss.NewStyle().MaxHeight(max(0, lipgloss.Height(content)-extra)).Render(content)
+	}
 	return m.Ctx.Styles.Section.ContainerStyle.Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
 			search,
-			m.GetMainContent(),
+			content,
 		),
 	)
 }
@@ -0,0 +1,133 @@
+package section
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+const (
+	snapshotsDirName = "sections"
+	// snapshotVersion is bumped whenever the stored items change shape, so
+	// old snapshots are ignored instead of half decoded.
+	snapshotVersion = 1
+)
+
+// Snapshot is the last successful first page of a section, kept on disk so
+// the next launch can show it while the section is refreshed.
+type Snapshot[T any] struct {
+	Version    int
+	SavedAt    time.Time
+	TotalCount int
+	Items      []T
+}
+
+// SnapshotKey identifies the results of a section: the kind of items, the
+// filters with the macros they reference expanded, the limit it fetches with
+// and the providers it fetches from.
+func SnapshotKey(
+	sectionType string,
+	filters string,
+	macros map[string]string,
+	limit int,
+	instances []providers.Instance,
+) string {
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.ID)
+	}
+	hash := sha256.New()
+	for _, part := range []string{sectionType, expandedFilters(filters, macros), strconv.Itoa(limit), strings.Join(ids, ",")} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// expandedFilters returns filters with its macros expanded. Filters that
+// don't parse are kept with every macro definition, since it can't be told
+// which of them they use.
+func expandedFilters(filters string, macros map[string]string) string {
+	if expr, err := dsl.ParseFilterWithMacros(filters, macros); err == nil {
+		return dsl.Format(expr)
+	}
+	names := slices.Sorted(maps.Keys(macros))
+	parts := []string{filters}
+	for _, name := range names {
+		parts = append(parts, name+"="+macros[name])
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// LoadSnapshot returns the snapshot saved under key, if there is a readable
+// one.
+func LoadSnapshot[T any](key string) (Snapshot[T], bool) {
+	path, ok := snapshotPath(key)
+	if !ok {
+		return Snapshot[T]{}, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot[T]{}, false
+	}
+	var snapshot Snapshot[T]
+	if err := json.Unmarshal(content, &snapshot); err != nil || snapshot.Version != snapshotVersion {
+		log.Debug("Ignoring section snapshot", "path", path, "err", err)
+		return Snapshot[T]{}, false
+	}
+	return snapshot, true
+}
+
+// SaveSnapshotCmd encodes items right away, since they may change once the
+// section handles its next message, and writes them under key in the
+// background.
+func SaveSnapshotCmd[T any](key string, items []T, totalCount int) tea.Cmd {
+	path, ok := snapshotPath(key)
+	if !ok {
+		return nil
+	}
+	content, err := json.Marshal(Snapshot[T]{
+		Version:    snapshotVersion,
+		SavedAt:    time.Now(),
+		TotalCount: totalCount,
+		Items:      items,
+	})
+	if err != nil {
+		log.Error("Failed encoding section snapshot", "err", err)
+		return nil
+	}
+	return func() tea.Msg {
+		if err := config.WriteCacheFile(path, content); err != nil {
+			log.Error("Failed saving section snapshot", "path", path, "err", err)
+		}
+		return nil
+	}
+}
+
+// snapshotPath returns where the snapshot for key lives. Mocked data is
+// never cached.
+func snapshotPath(key string) (string, bool) {
+	if key == "" || data.IsClientOverride() || config.IsFeatureEnabled(config.FF_MOCK_DATA) {
+		return "", false
+	}
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(cacheDir, snapshotsDirName, key+".json"), true
+}
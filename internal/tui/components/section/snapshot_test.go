@@ -0,0 +1,75 @@
+package section
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	instances := []providers.Instance{{ID: "github:github.com", Kind: providers.KindGitHub}}
+	key := SnapshotKey("issue", `state = "open"`, nil, 20, instances)
+
+	if _, ok := LoadSnapshot[domain.Issue](key); ok {
+		t.Fatalf("expected no snapshot before saving")
+	}
+
+	issue := data.IssueData{Number: 7, Title: "Fix startup"}
+	items := []domain.Issue{domain.NewIssueFromDataWithProvider(issue, "github:github.com")}
+	cmd := SaveSnapshotCmd(key, items, 3)
+	if cmd == nil {
+		t.Fatalf("expected a command writing the snapshot")
+	}
+	items[0].Data.Title = "changed after saving"
+	cmd()
+
+	snapshot, ok := LoadSnapshot[domain.Issue](key)
+	if !ok {
+		t.Fatalf("expected the saved snapshot")
+	}
+	if snapshot.TotalCount != 3 {
+		t.Fatalf("expected total count 3, got %d", snapshot.TotalCount)
+	}
+	want := []domain.Issue{domain.NewIssueFromDataWithProvider(issue, "github:github.com")}
+	if !reflect.DeepEqual(snapshot.Items, want) {
+		t.Fatalf("unexpected items: %#v", snapshot.Items)
+	}
+}
+
+func TestSnapshotKeyDependsOnQuery(t *testing.T) {
+	github := []providers.Instance{{ID: "github:github.com"}}
+	gitlab := []providers.Instance{{ID: "gitlab:gitlab.com"}}
+	base := SnapshotKey("pr", `state = "open"`, nil, 20, github)
+	for name, other := range map[string]string{
+		"type":      SnapshotKey("issue", `state = "open"`, nil, 20, github),
+		"filters":   SnapshotKey("pr", `state = "closed"`, nil, 20, github),
+		"limit":     SnapshotKey("pr", `state = "open"`, nil, 50, github),
+		"providers": SnapshotKey("pr", `state = "open"`, nil, 20, gitlab),
+	} {
+		if other == base {
+			t.Fatalf("expected the key to change with the %s", name)
+		}
+	}
+}
+
+func TestSnapshotKeyDependsOnMacros(t *testing.T) {
+	github := []providers.Instance{{ID: "github:github.com"}}
+	key := func(filters string, macros map[string]string) string {
+		return SnapshotKey("pr", filters, macros, 20, github)
+	}
+
+	mine := key(`@mine`, map[string]string{"mine": `author = "alice"`})
+	if mine == key(`@mine`, map[string]string{"mine": `author = "bob"`}) {
+		t.Fatalf("expected the key to change with the macro")
+	}
+	if mine != key(`author = "alice"`, nil) {
+		t.Fatalf("expected the key of the expanded filter")
+	}
+	if key(`@mine`, nil) == key(`@mine`, map[string]string{"mine": `author = "bob"`}) {
+		t.Fatalf("expected the key to change once the macro is defined")
+	}
+}
@@ -0,0 +1,202 @@
+package section
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/dsl"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+)
+
+// ProviderResult is what one provider of a multi-provider fetch returned.
+// Page is nil when the provider was skipped or failed.
+type ProviderResult[T RefreshItem] struct {
+	ProviderID      string
+	Items           []T
+	Page            *ProviderPage
+	Err             error
+	Order           *dsl.OrderBy
+	LocallyFiltered bool
+}
+
+// ProviderStream collects the results of a multi-provider fetch as each
+// provider answers, so the section can show them before all have.
+type ProviderStream[T RefreshItem] struct {
+	TaskId string
+	// FirstPage is set when the stream replaces the rows, otherwise it is
+	// appended to Base, the rows fetched before.
+	FirstPage bool
+	Base      []T
+	// Held are the items the previous page fetched but didn't show, since
+	// they sorted after its frontier. They are shown along with this page.
+	Held []T
+	// Providers is the order results are merged in when items tie.
+	Providers       []string
+	Pending         map[string]bool
+	Items           map[string][]T
+	Fetched         map[string]ProviderPage
+	Errors          map[string]string
+	Order           dsl.OrderBy
+	LocallyFiltered bool
+}
+
+func NewProviderStream[T RefreshItem](taskId string, providerIDs []string) *ProviderStream[T] {
+	pending := make(map[string]bool, len(providerIDs))
+	for _, id := range providerIDs {
+		pending[id] = true
+	}
+	return &ProviderStream[T]{
+		TaskId:    taskId,
+		Providers: providerIDs,
+		Pending:   pending,
+		Items:     map[string][]T{},
+		Fetched:   map[string]ProviderPage{},
+		Errors:    map[string]string{},
+		Order:     dsl.DefaultOrder,
+	}
+}
+
+// Add records the result of a provider.
+func (s *ProviderStream[T]) Add(result ProviderResult[T]) {
+	delete(s.Pending, result.ProviderID)
+	if result.Err != nil {
+		s.Errors[result.ProviderID] = result.Err.Error()
+		return
+	}
+	if result.Page != nil {
+		s.Fetched[result.ProviderID] = *result.Page
+	}
+	s.Items[result.ProviderID] = result.Items
+	if result.Order != nil {
+		s.Order = *result.Order
+	}
+	if result.LocallyFiltered {
+		s.LocallyFiltered = true
+	}
+}
+
+// Done reports whether every provider has answered.
+func (s *ProviderStream[T]) Done() bool {
+	return len(s.Pending) == 0
+}
+
+// Failed reports whether the providers that answered so far all failed.
+// The rows fetched before are kept then, as there is nothing to replace them
+// with.
+func (s *ProviderStream[T]) Failed() bool {
+	return len(s.Errors) > 0 && len(s.Items) == 0
+}
+
+// Err returns what went wrong when every provider failed.
+func (s *ProviderStream[T]) Err() error {
+	if !s.Failed() {
+		return nil
+	}
+	errs := make([]error, 0, len(s.Errors))
+	for _, id := range s.Providers {
+		if err, ok := s.Errors[id]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Rows returns the rows fetched before followed by the results so far,
+// sorted, up to the frontier of the providers with more pages. Results
+// after it could be preceded by an item of a later page, so they are held
+// back, see Rest.
+func (s *ProviderStream[T]) Rows() []T {
+	shown, _ := s.split()
+	return AppendPage(slices.Clone(s.Base), shown)
+}
+
+// Rest returns the results held back from the rows, to be shown with the
+// next page.
+func (s *ProviderStream[T]) Rest() []T {
+	_, rest := s.split()
+	return rest
+}
+
+func (s *ProviderStream[T]) split() ([]T, []T) {
+	lists := append([][]T{s.Held}, s.items()...)
+	merged := dsl.MergeSubjects(lists, s.Order)
+	frontier, ok := s.frontier()
+	if !ok {
+		return merged, nil
+	}
+	cut := len(merged)
+	for i, item := range merged {
+		if dsl.CompareInOrder(item, frontier, s.Order) > 0 {
+			cut = i
+			break
+		}
+	}
+	return merged[:cut], merged[cut:]
+}
+
+func (s *ProviderStream[T]) items() [][]T {
+	lists := make([][]T, 0, len(s.Providers))
+	for _, id := range s.Providers {
+		lists = append(lists, s.Items[id])
+	}
+	return lists
+}
+
+// frontier returns the last result of the provider with more pages whose
+// results end first in the order, as its next page sorts after it. There is
+// none when no provider that returned results has more pages.
+func (s *ProviderStream[T]) frontier() (T, bool) {
+	var frontier T
+	found := false
+	for _, id := range s.Providers {
+		page, ok := s.Fetched[id]
+		items := s.Items[id]
+		if !ok || !page.PageInfo.HasNextPage || len(items) == 0 {
+			continue
+		}
+		last := slices.MaxFunc(items, func(a, b T) int {
+			return dsl.CompareInOrder(a, b, s.Order)
+		})
+		if !found || dsl.CompareInOrder(last, frontier, s.Order) < 0 {
+			frontier = last
+			found = true
+		}
+	}
+	return frontier, found
+}
+
+// FinishStreamCmd finishes the task of a stream that won't send its result,
+// because a later fetch of the section superseded it or it had nothing to
+// fetch.
+func FinishStreamCmd(sectionId int, sectionType string, taskId string) tea.Cmd {
+	return func() tea.Msg {
+		return constants.TaskFinishedMsg{
+			SectionId:   sectionId,
+			SectionType: sectionType,
+			TaskId:      taskId,
+		}
+	}
+}
+
+// PendingSummary lists the providers a section is still waiting for.
+func PendingSummary(ctx *context.ProgramContext, pending map[string]bool) string {
+	if len(pending) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(pending))
+	for providerID := range pending {
+		name := providerID
+		if provider, ok := ctx.ProviderByID(providerID); ok {
+			name = provider.DisplayName
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%s loading %s", constants.WaitingIcon, strings.Join(names, ", "))
+}
@@ -0,0 +1,102 @@
+package section
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+func TestProviderStream(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(providerID string, number int, updated time.Duration) domain.Issue {
+		return domain.NewIssueFromDataWithProvider(data.IssueData{
+			Number:     number,
+			UpdatedAt:  base.Add(updated),
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, providerID)
+	}
+	stream := NewProviderStream[domain.Issue]("task", []string{"github:github.com", "gitlab:gitlab.com", "gitlab:example.com"})
+	stream.Base = []domain.Issue{issue("github:github.com", 1, 0)}
+
+	stream.Add(ProviderResult[domain.Issue]{
+		ProviderID: "gitlab:gitlab.com",
+		Items:      []domain.Issue{issue("gitlab:gitlab.com", 2, time.Hour), issue("gitlab:gitlab.com", 3, -time.Hour)},
+		Page:       &ProviderPage{TotalCount: 2},
+	})
+	if stream.Done() || len(stream.Pending) != 2 {
+		t.Fatalf("expected two providers pending, got %v", stream.Pending)
+	}
+	var numbers []int
+	for _, row := range stream.Rows() {
+		numbers = append(numbers, row.Data.Number)
+	}
+	if len(numbers) != 3 || numbers[0] != 1 || numbers[1] != 2 || numbers[2] != 3 {
+		t.Fatalf("expected the results sorted by update time after the rows, got %v", numbers)
+	}
+
+	stream.Add(ProviderResult[domain.Issue]{ProviderID: "github:github.com", Page: &ProviderPage{}})
+	stream.Add(ProviderResult[domain.Issue]{ProviderID: "gitlab:example.com", Err: errors.New("timeout")})
+	if !stream.Done() {
+		t.Fatalf("expected every provider to have answered")
+	}
+	if stream.Errors["gitlab:example.com"] != "timeout" || len(stream.Fetched) != 2 {
+		t.Fatalf("unexpected errors %v or pages %v", stream.Errors, stream.Fetched)
+	}
+	if stream.Failed() || stream.Err() != nil {
+		t.Fatalf("expected a stream with results not to have failed")
+	}
+}
+
+func TestProviderStreamHoldsResultsPastTheFrontier(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(providerID string, number int, updated time.Duration) domain.Issue {
+		return domain.NewIssueFromDataWithProvider(data.IssueData{
+			Number:     number,
+			UpdatedAt:  base.Add(updated),
+			Repository: data.Repository{NameWithOwner: "acme/app"},
+		}, providerID)
+	}
+	numbers := func(issues []domain.Issue) []int {
+		var numbers []int
+		for _, issue := range issues {
+			numbers = append(numbers, issue.Data.Number)
+		}
+		return numbers
+	}
+	stream := NewProviderStream[domain.Issue]("task", []string{"github:github.com", "gitlab:gitlab.com"})
+	stream.Held = []domain.Issue{issue("gitlab:gitlab.com", 5, -4*time.Hour)}
+	stream.Add(ProviderResult[domain.Issue]{
+		ProviderID: "github:github.com",
+		Items:      []domain.Issue{issue("github:github.com", 1, 0), issue("github:github.com", 2, -2*time.Hour)},
+		Page:       &ProviderPage{PageInfo: data.PageInfo{HasNextPage: true}},
+	})
+	stream.Add(ProviderResult[domain.Issue]{
+		ProviderID: "gitlab:gitlab.com",
+		Items:      []domain.Issue{issue("gitlab:gitlab.com", 3, -time.Hour), issue("gitlab:gitlab.com", 4, -3*time.Hour)},
+		Page:       &ProviderPage{},
+	})
+
+	if got := numbers(stream.Rows()); !slices.Equal(got, []int{1, 3, 2}) {
+		t.Fatalf("expected the rows up to github's last result, got %v", got)
+	}
+	if got := numbers(stream.Rest()); !slices.Equal(got, []int{4, 5}) {
+		t.Fatalf("expected the results after it to be held, got %v", got)
+	}
+}
+
+func TestProviderStreamFailed(t *testing.T) {
+	stream := NewProviderStream[domain.Issue]("task", []string{"github:github.com", "gitlab:gitlab.com"})
+	stream.Add(ProviderResult[domain.Issue]{ProviderID: "gitlab:gitlab.com", Err: errors.New("no such host")})
+	if !stream.Failed() {
+		t.Fatalf("expected the stream to have failed so far")
+	}
+	stream.Add(ProviderResult[domain.Issue]{ProviderID: "github:github.com", Err: errors.New("connection refused")})
+	want := "github:github.com: connection refused\ngitlab:gitlab.com: no such host"
+	if err := stream.Err(); err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
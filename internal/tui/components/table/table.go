@@ -135,6 +135,13 @@ func (m *Model) NextItem() int {
 	return currItem
 }
 
+func (m *Model) SetCurrItem(id int) int {
+	currItem := m.rowsViewport.SetCurrItem(id)
+	m.SyncViewPortContent()
+
+	return currItem
+}
+
 func (m *Model) FirstItem() int {
 	currItem := m.rowsViewport.FirstItem()
 	m.SyncViewPortContent()
@@ -0,0 +1,275 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
+)
+
+// ActionQueuedMsg is sent instead of a TaskFinishedMsg when an action was
+// taken while offline and has to be queued in the journal.
+type ActionQueuedMsg struct {
+	TaskId      string
+	SectionId   int
+	SectionType string
+	Action      journal.Action
+	// Msg updates the section as if the action had been sent.
+	Msg tea.Msg
+}
+
+// ActionResult is how sending a queued action went.
+type ActionResult struct {
+	Action journal.Action
+	State  journal.State
+	Err    error
+}
+
+// ActionsReplayedMsg is sent once the queued actions were replayed. Offline
+// is set when the providers became unreachable again halfway, the actions
+// without a result are still queued then.
+type ActionsReplayedMsg struct {
+	TaskId  string
+	Results []ActionResult
+	Offline bool
+}
+
+// PerformAction runs action and sends msg to the section once it's done.
+// While offline, or when the action fails because its host can't be
+// reached, the action is queued instead. Actions that aren't idempotent are
+// only queued when they never left the client, one that failed on the way
+// may have been applied.
+func PerformAction(
+	ctx *context.ProgramContext,
+	section SectionIdentifier,
+	task context.Task,
+	action journal.Action,
+	msg tea.Msg,
+) tea.Cmd {
+	offline := ctx.Offline
+	startCmd := ctx.StartTask(task)
+	return tea.Batch(startCmd, func() tea.Msg {
+		queued := ActionQueuedMsg{
+			TaskId:      task.Id,
+			SectionId:   section.Id,
+			SectionType: section.Type,
+			Action:      action,
+			Msg:         msg,
+		}
+		if offline {
+			return queued
+		}
+		err := RunAction(ctx, action)
+		if queueable(action, err) && !data.IsReachable(actionHost(ctx, action)) {
+			log.Info("Host unreachable, queueing action", "kind", action.Kind, "key", action.Key, "err", err)
+			return queued
+		}
+		return constants.TaskFinishedMsg{
+			SectionId:   section.Id,
+			SectionType: section.Type,
+			TaskId:      task.Id,
+			Err:         err,
+			Msg:         msg,
+		}
+	})
+}
+
+// RunAction sends action to the provider of its item.
+func RunAction(ctx *context.ProgramContext, action journal.Action) error {
+	if provider, ok := ctx.ProviderByID(action.Key.ProviderID); ok && provider.Kind == providers.KindGitLab {
+		return runGitLabAction(provider, action)
+	}
+	args, err := githubActionArgs(action)
+	if err != nil {
+		return err
+	}
+	return ghcli.Run(ghcli.CommandForProvider(ctx, action.Key.ProviderID, args...), action.Kind.Idempotent())
+}
+
+func githubActionArgs(action journal.Action) ([]string, error) {
+	item := "issue"
+	if action.Key.Type == domain.WorkItemPullRequest {
+		item = "pr"
+	}
+	number := fmt.Sprint(action.Key.Number)
+	switch action.Kind {
+	case journal.KindComment:
+		return []string{item, "comment", number, "-R", action.Repo, "-b", action.Body}, nil
+	case journal.KindLabel:
+		args := []string{item, "edit", number, "-R", action.Repo}
+		for _, label := range action.Current {
+			if !containsFold(action.Values, label) {
+				args = append(args, "--remove-label", label)
+			}
+		}
+		for _, label := range action.Values {
+			args = append(args, "--add-label", label)
+		}
+		return args, nil
+	case journal.KindAssign, journal.KindUnassign:
+		flag := "--add-assignee"
+		if action.Kind == journal.KindUnassign {
+			flag = "--remove-assignee"
+		}
+		args := []string{item, "edit", number, "-R", action.Repo}
+		for _, assignee := range action.Values {
+			args = append(args, flag, assignee)
+		}
+		return args, nil
+	case journal.KindApprove:
+		args := []string{"pr", "review", "-R", action.Repo, number, "--approve"}
+		if action.Body != "" {
+			args = append(args, "--body", action.Body)
+		}
+		return args, nil
+	case journal.KindClose:
+		return []string{item, "close", number, "-R", action.Repo}, nil
+	}
+	return nil, fmt.Errorf("unsupported action %q", action.Kind)
+}
+
+func runGitLabAction(provider providers.Instance, action journal.Action) error {
+	repoPath := action.Key.RepoPath
+	number := action.Key.Number
+	isMergeRequest := action.Key.Type == domain.WorkItemPullRequest
+	switch action.Kind {
+	case journal.KindComment:
+		if isMergeRequest {
+			return data.GitLabMergeRequestComment(provider, repoPath, number, action.Body)
+		}
+		return data.GitLabIssueComment(provider, repoPath, number, action.Body)
+	case journal.KindLabel:
+		if isMergeRequest {
+			return data.GitLabSetMergeRequestLabels(provider, repoPath, number, action.Values)
+		}
+		return data.GitLabSetIssueLabels(provider, repoPath, number, action.Values)
+	case journal.KindAssign, journal.KindUnassign:
+		// GitLab replaces the assignees, so send the whole list
+		assignees := make([]string, 0, len(action.Current)+len(action.Values))
+		for _, assignee := range action.Current {
+			if action.Kind == journal.KindAssign || !containsFold(action.Values, assignee) {
+				assignees = append(assignees, assignee)
+			}
+		}
+		if action.Kind == journal.KindAssign {
+			for _, assignee := range action.Values {
+				if trimmed := strings.TrimSpace(assignee); trimmed != "" && !containsFold(assignees, trimmed) {
+					assignees = append(assignees, trimmed)
+				}
+			}
+		}
+		if isMergeRequest {
+			return data.GitLabSetMergeRequestAssignees(provider, repoPath, number, assignees)
+		}
+		return data.GitLabSetIssueAssignees(provider, repoPath, number, assignees)
+	case journal.KindApprove:
+		return data.GitLabMergeRequestApprove(provider, repoPath, number, action.Body)
+	case journal.KindClose:
+		if isMergeRequest {
+			return data.GitLabSetMergeRequestState(provider, repoPath, number, "close")
+		}
+		return data.GitLabSetIssueState(provider, repoPath, number, "close")
+	}
+	return fmt.Errorf("unsupported action %q", action.Kind)
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplayActions sends the queued actions in order. Actions whose item
+// changed after they were queued aren't sent but reported as conflicts, and
+// replaying stops when the providers can't be reached again, unless the
+// action that failed may have been applied, which is reported as failed.
+func ReplayActions(ctx *context.ProgramContext, taskId string, actions []journal.Action) tea.Cmd {
+	return func() tea.Msg {
+		msg := ActionsReplayedMsg{TaskId: taskId}
+		// look conflicts up before sending anything, so the actions sent
+		// here don't count as changes to the items of the later ones
+		conflicts := findConflicts(ctx, actions)
+		for _, action := range actions {
+			if conflicts[action.Id] {
+				msg.Results = append(msg.Results, ActionResult{Action: action, State: journal.StateConflict})
+				continue
+			}
+			err := RunAction(ctx, action)
+			if queueable(action, err) && !data.IsReachable(actionHost(ctx, action)) {
+				msg.Offline = true
+				break
+			}
+			state := journal.StateSynced
+			if err != nil {
+				state = journal.StateFailed
+			}
+			msg.Results = append(msg.Results, ActionResult{Action: action, State: state, Err: err})
+		}
+		return msg
+	}
+}
+
+// findConflicts returns the ids of the actions whose item was updated after
+// it was last seen. Items that can't be looked up are assumed unchanged.
+func findConflicts(ctx *context.ProgramContext, actions []journal.Action) map[int]bool {
+	type lookup struct {
+		key   domain.WorkItemKey
+		since time.Time
+	}
+	changed := map[lookup]bool{}
+	conflicts := map[int]bool{}
+	for _, action := range actions {
+		provider, ok := ctx.ProviderByID(action.Key.ProviderID)
+		if !ok || action.ItemUpdatedAt.IsZero() {
+			continue
+		}
+		l := lookup{key: action.Key, since: action.ItemUpdatedAt}
+		if _, ok := changed[l]; !ok {
+			resource := data.GitLabIssues
+			if action.Key.Type == domain.WorkItemPullRequest {
+				resource = data.GitLabMergeRequests
+			}
+			ref := data.ItemRef{Repo: action.Repo, Number: action.Key.Number}
+			if provider.Kind == providers.KindGitLab {
+				ref.Repo = action.Key.RepoPath
+			}
+			updated, err := data.UpdatedSince(provider, resource, []data.ItemRef{ref}, action.ItemUpdatedAt)
+			if err != nil {
+				log.Error("Failed checking queued action for conflicts", "key", action.Key, "err", err)
+			}
+			changed[l] = updated[ref]
+		}
+		conflicts[action.Id] = changed[l]
+	}
+	return conflicts
+}
+
+// queueable reports whether action, which failed with err, can be sent again
+// later without being applied twice.
+func queueable(action journal.Action, err error) bool {
+	if err == nil {
+		return false
+	}
+	return action.Kind.Idempotent() || data.IsUnsentWriteError(err)
+}
+
+func actionHost(ctx *context.ProgramContext, action journal.Action) string {
+	if provider, ok := ctx.ProviderByID(action.Key.ProviderID); ok {
+		return provider.Host
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
+)
+
+func TestGitHubLabelActionArgs(t *testing.T) {
+	action := journal.Action{
+		Kind:    journal.KindLabel,
+		Key:     domain.NewWorkItemKey("", "owner/repo", 7, domain.WorkItemIssue),
+		Repo:    "owner/repo",
+		Values:  []string{"bug", "ui"},
+		Current: []string{"bug", "stale"},
+	}
+	args, err := githubActionArgs(action)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"issue", "edit", "7", "-R", "owner/repo", "--remove-label", "stale", "--add-label", "bug", "--add-label", "ui"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestReplayActionsSkipsConflicts(t *testing.T) {
+	var mu sync.Mutex
+	var writes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// issue 1 was updated since the actions were queued
+			w.Write([]byte(`[{"iid":1}]`))
+			return
+		}
+		mu.Lock()
+		writes = append(writes, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	provider := providers.Instance{ID: "gitlab:replay", Kind: providers.KindGitLab, Host: server.URL}
+	ctx := &context.ProgramContext{Providers: []providers.Instance{provider}}
+	queuedAt := time.Now().Add(-time.Hour)
+	actions := []journal.Action{
+		{
+			Id:            1,
+			Kind:          journal.KindClose,
+			Key:           domain.NewWorkItemKey(provider.ID, "group/project", 1, domain.WorkItemIssue),
+			ItemUpdatedAt: queuedAt,
+		},
+		{
+			Id:            2,
+			Kind:          journal.KindClose,
+			Key:           domain.NewWorkItemKey(provider.ID, "group/project", 2, domain.WorkItemIssue),
+			ItemUpdatedAt: queuedAt,
+		},
+	}
+
+	msg := ReplayActions(ctx, "replay", actions)().(ActionsReplayedMsg)
+	if msg.Offline || len(msg.Results) != 2 {
+		t.Fatalf("unexpected replay %+v", msg)
+	}
+	if msg.Results[0].State != journal.StateConflict || msg.Results[1].State != journal.StateSynced {
+		t.Fatalf("expected a conflict and a synced action, got %+v", msg.Results)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(writes) != 1 || !strings.HasSuffix(writes[0], "/issues/2") || !strings.HasPrefix(writes[0], http.MethodPut) {
+		t.Fatalf("expected only issue 2 to be closed, got %v", writes)
+	}
+}
+
+func TestQueueableActions(t *testing.T) {
+	unsent := &data.WriteError{Kind: data.WriteErrorUnreachable, Err: errors.New("dial tcp: connection refused")}
+	sent := &data.WriteError{Kind: data.WriteErrorNetwork, Err: errors.New("connection reset by peer")}
+	cases := []struct {
+		kind journal.Kind
+		err  error
+		want bool
+	}{
+		{journal.KindClose, nil, false},
+		{journal.KindClose, sent, true},
+		{journal.KindComment, unsent, true},
+		{journal.KindComment, sent, false},
+		{journal.KindApprove, errors.New("exit status 1"), false},
+	}
+	for _, c := range cases {
+		if got := queueable(journal.Action{Kind: c.kind}, c.err); got != c.want {
+			t.Errorf("queueable(%s, %v) = %v, want %v", c.kind, c.err, got, c.want)
+		}
+	}
+}
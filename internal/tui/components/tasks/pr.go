@@ -14,6 +14,7 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/ghcli"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 	"github.com/dlvhdr/gh-dash/v4/internal/utils"
 )
 
@@ -50,7 +51,10 @@ type GitHubTask struct {
 	Section      SectionIdentifier
 	StartText    string
 	FinishedText string
-	Msg          func(c *exec.Cmd, err error) tea.Msg
+	// Idempotent is set for commands that have the same effect however
+	// often they run, which are retried on transient errors.
+	Idempotent bool
+	Msg        func(c *exec.Cmd, err error) tea.Msg
 }
 
 func fireTask(ctx *context.ProgramContext, task GitHubTask) tea.Cmd {
@@ -74,7 +78,7 @@ func fireTask(ctx *context.ProgramContext, task GitHubTask) tea.Cmd {
 			c = exec.Command("gh", task.Args...)
 		}
 
-		err := c.Run()
+		err := ghcli.Run(c, task.Idempotent)
 		return constants.TaskFinishedMsg{
 			TaskId:      task.Id,
 			SectionId:   task.Section.Id,
@@ -131,6 +135,7 @@ func ReopenPR(ctx *context.ProgramContext, section SectionIdentifier, pr domain.
 		Section:      section,
 		StartText:    fmt.Sprintf("Reopening PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been reopened", prNumber),
+		Idempotent:   true,
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				Key:      pr.Key(),
@@ -143,44 +148,17 @@ func ReopenPR(ctx *context.ProgramContext, section SectionIdentifier, pr domain.
 
 func ClosePR(ctx *context.ProgramContext, section SectionIdentifier, pr domain.WorkItem) tea.Cmd {
 	prNumber := pr.GetNumber()
-	if provider, ok := ctx.ProviderForItem(pr); ok && provider.Kind == providers.KindGitLab {
-		taskId := buildTaskId("pr_close", prNumber)
-		task := context.Task{
-			Id:           taskId,
-			StartText:    fmt.Sprintf("Closing PR #%d", prNumber),
-			FinishedText: fmt.Sprintf("PR #%d has been closed", prNumber),
-			State:        context.TaskStart,
-			Error:        nil,
-		}
-		startCmd := ctx.StartTask(task)
-		return tea.Batch(startCmd, func() tea.Msg {
-			err := data.GitLabSetMergeRequestState(provider, pr.Key().RepoPath, prNumber, "close")
-			return constants.TaskFinishedMsg{
-				SectionId:   section.Id,
-				SectionType: section.Type,
-				TaskId:      taskId,
-				Err:         err,
-				Msg: UpdatePRMsg{
-					Key:      pr.Key(),
-					PrNumber: prNumber,
-					IsClosed: utils.BoolPtr(true),
-				},
-			}
-		})
-	}
-	return fireTask(ctx, GitHubTask{
+	task := context.Task{
 		Id:           buildTaskId("pr_close", prNumber),
-		Command:      ghcli.CommandForItem(ctx, pr, "pr", "close", fmt.Sprint(prNumber), "-R", pr.GetRepoNameWithOwner()),
-		Section:      section,
 		StartText:    fmt.Sprintf("Closing PR #%d", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been closed", prNumber),
-		Msg: func(c *exec.Cmd, err error) tea.Msg {
-			return UpdatePRMsg{
-				Key:      pr.Key(),
-				PrNumber: prNumber,
-				IsClosed: utils.BoolPtr(true),
-			}
-		},
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	return PerformAction(ctx, section, task, journal.NewAction(journal.KindClose, pr), UpdatePRMsg{
+		Key:      pr.Key(),
+		PrNumber: prNumber,
+		IsClosed: utils.BoolPtr(true),
 	})
 }
 
@@ -211,6 +189,7 @@ func PRReady(ctx *context.ProgramContext, section SectionIdentifier, pr domain.W
 		Section:      section,
 		StartText:    fmt.Sprintf("Marking PR #%d as ready for review", prNumber),
 		FinishedText: fmt.Sprintf("PR #%d has been marked as ready for review", prNumber),
+		Idempotent:   true,
 		Msg: func(c *exec.Cmd, err error) tea.Msg {
 			return UpdatePRMsg{
 				Key:            pr.Key(),
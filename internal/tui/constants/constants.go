@@ -33,6 +33,7 @@ const (
 	EmptyIcon   = ""
 	FailureIcon = "󰅙"
 	SuccessIcon = ""
+	FilterIcon  = ""
 
 	CommentIcon  = ""
 	CommentsIcon = ""
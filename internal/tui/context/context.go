@@ -9,6 +9,7 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/config"
 	"github.com/dlvhdr/gh-dash/v4/internal/domain"
 	"github.com/dlvhdr/gh-dash/v4/internal/providers"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
 	"github.com/dlvhdr/gh-dash/v4/internal/utils"
 )
@@ -49,6 +50,15 @@ type ProgramContext struct {
 	Styles            Styles
 	Providers         []providers.Instance
 	GroupByProvider   bool
+	// FilterValues holds values per DSL field seen in the loaded sections,
+	// offered when completing filters in the search bar.
+	FilterValues map[string][]string
+	// Store holds the loaded work items shared by all sections.
+	Store *domain.Store
+	// Offline is set while the providers can't be reached. Sections keep
+	// showing their cached rows and write actions are queued in Journal.
+	Offline bool
+	Journal *journal.Journal
 }
 
 func (ctx *ProgramContext) GetViewSectionsConfig() []config.SectionConfig {
@@ -84,6 +94,15 @@ func (ctx *ProgramContext) ProvidersByKind(kind providers.Kind) []providers.Inst
 	return out
 }
 
+// FilterMacros returns the named filters of the config, which section and
+// search bar filters may reference.
+func (ctx *ProgramContext) FilterMacros() map[string]string {
+	if ctx == nil || ctx.Config == nil {
+		return nil
+	}
+	return ctx.Config.Filters
+}
+
 func (ctx *ProgramContext) ProviderByID(providerID string) (providers.Instance, bool) {
 	for _, provider := range ctx.Providers {
 		if provider.ID == providerID {
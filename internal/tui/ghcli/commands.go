@@ -1,8 +1,11 @@
 package ghcli
 
 import (
+	"bytes"
+	"io"
 	"os/exec"
 
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
 	"github.com/dlvhdr/gh-dash/v4/internal/domain"
 	"github.com/dlvhdr/gh-dash/v4/internal/git"
 	"github.com/dlvhdr/gh-dash/v4/internal/providers"
@@ -11,7 +14,11 @@ import (
 )
 
 func CommandForItem(ctx *context.ProgramContext, item domain.WorkItem, args ...string) *exec.Cmd {
-	provider := resolveProvider(ctx, providerIDFromItem(item), "")
+	return CommandForProvider(ctx, providerIDFromItem(item), args...)
+}
+
+func CommandForProvider(ctx *context.ProgramContext, providerID string, args ...string) *exec.Cmd {
+	provider := resolveProvider(ctx, providerID, "")
 	return ghprovider.Provider{Instance: provider}.Command(args...)
 }
 
@@ -47,3 +54,46 @@ func resolveProvider(ctx *context.ProgramContext, providerID, repoURL string) pr
 	}
 	return providers.NewInstance(providers.KindGitHub, "")
 }
+
+// Run runs c, a gh command that writes to its host, and returns its failure
+// as a data.WriteError. Idempotent commands, which have the same effect
+// however often they run, are retried on transient errors. Output c already
+// writes somewhere still goes there.
+func Run(c *exec.Cmd, idempotent bool) error {
+	host := hostFromArgs(c.Args)
+	stdout, callerStderr := c.Stdout, c.Stderr
+	attempt := 0
+	run := func() error {
+		cmd := c
+		if attempt > 0 {
+			// a command only runs once, so retries run a copy
+			cmd = exec.Command(c.Path, c.Args[1:]...)
+			cmd.Env = c.Env
+			cmd.Dir = c.Dir
+			cmd.Stdout = stdout
+		}
+		attempt++
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if callerStderr != nil {
+			cmd.Stderr = io.MultiWriter(callerStderr, &stderr)
+		}
+		err := cmd.Run()
+		return data.CLIWriteError(host, stderr.String(), err)
+	}
+	if !idempotent {
+		return run()
+	}
+	return data.RetryIdempotentWrite(run)
+}
+
+// hostFromArgs returns the host the command targets, set by the providers'
+// commands with --hostname.
+func hostFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--hostname" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package ghcli
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+)
+
+func TestRunClassifiesWhenStderrIsSet(t *testing.T) {
+	var output bytes.Buffer
+	cmd := exec.Command("sh", "-c", `echo "HTTP 404: Not Found (https://api.github.com/repos/o/r)" >&2; exit 1`)
+	cmd.Stderr = &output
+
+	err := Run(cmd, false)
+	var writeErr *data.WriteError
+	if !errors.As(err, &writeErr) || writeErr.Kind != data.WriteErrorNotFound {
+		t.Fatalf("expected a not found write error, got %v", err)
+	}
+	if !strings.Contains(output.String(), "HTTP 404") {
+		t.Fatalf("expected the output to still reach the caller, got %q", output.String())
+	}
+}
@@ -0,0 +1,189 @@
+// Package journal keeps the write actions taken while offline, so they can
+// be sent once the dashboard is back online.
+package journal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/config"
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+type Kind string
+
+const (
+	KindComment  Kind = "comment"
+	KindLabel    Kind = "label"
+	KindAssign   Kind = "assign"
+	KindUnassign Kind = "unassign"
+	KindApprove  Kind = "approve"
+	KindClose    Kind = "close"
+)
+
+// Idempotent reports whether actions of kind have the same effect however
+// often they are sent, so they are safe to retry.
+func (k Kind) Idempotent() bool {
+	switch k {
+	case KindLabel, KindAssign, KindUnassign, KindClose:
+		return true
+	}
+	return false
+}
+
+type State string
+
+const (
+	StateQueued State = "queued"
+	StateSynced State = "synced"
+	// StateConflict is an action that wasn't sent because its item changed
+	// after it was queued.
+	StateConflict State = "conflict"
+	StateFailed   State = "failed"
+)
+
+// Action is a write action on a pull request or issue.
+type Action struct {
+	Id   int
+	Kind Kind
+	Key  domain.WorkItemKey
+	// Repo is the name with owner of the item's repository.
+	Repo string
+	// ItemUpdatedAt is when the item was last updated as far as the
+	// dashboard knew when the action was taken.
+	ItemUpdatedAt time.Time
+	Body          string
+	// Values are the labels or assignees the action sets, adds or removes,
+	// and Current the ones the item had.
+	Values   []string
+	Current  []string
+	QueuedAt time.Time
+	State    State
+	Err      string `json:",omitempty"`
+}
+
+// NewAction returns an action of kind on item.
+func NewAction(kind Kind, item domain.WorkItem) Action {
+	return Action{
+		Kind:          kind,
+		Key:           item.Key(),
+		Repo:          item.GetRepoNameWithOwner(),
+		ItemUpdatedAt: item.GetUpdatedAt(),
+	}
+}
+
+// Journal is the list of actions queued while offline, saved to disk so
+// they survive a restart. It is only used from the program's update loop.
+type Journal struct {
+	path    string
+	Actions []Action
+	nextId  int
+}
+
+const journalFileName = "journal.json"
+
+// Load reads the journal saved on disk. Only the actions still queued are
+// kept, the others were reported in the run that sent them.
+func Load() *Journal {
+	j := &Journal{}
+	path, ok := journalPath()
+	if !ok {
+		return j
+	}
+	j.path = path
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return j
+	}
+	var actions []Action
+	if err := json.Unmarshal(content, &actions); err != nil {
+		log.Error("Ignoring unreadable journal", "path", path, "err", err)
+		return j
+	}
+	for _, action := range actions {
+		j.nextId = max(j.nextId, action.Id)
+		if action.State == StateQueued {
+			j.Actions = append(j.Actions, action)
+		}
+	}
+	return j
+}
+
+// Add queues action and returns it as queued.
+func (j *Journal) Add(action Action) Action {
+	j.nextId++
+	action.Id = j.nextId
+	action.QueuedAt = time.Now()
+	action.State = StateQueued
+	j.Actions = append(j.Actions, action)
+	j.save()
+	return action
+}
+
+// Queued returns the actions that are still to be sent, oldest first.
+func (j *Journal) Queued() []Action {
+	var queued []Action
+	for _, action := range j.Actions {
+		if action.State == StateQueued {
+			queued = append(queued, action)
+		}
+	}
+	return queued
+}
+
+// Resolve records how sending the action with id went.
+func (j *Journal) Resolve(id int, state State, err error) {
+	for i := range j.Actions {
+		if j.Actions[i].Id != id {
+			continue
+		}
+		j.Actions[i].State = state
+		j.Actions[i].Err = ""
+		if err != nil {
+			j.Actions[i].Err = err.Error()
+		}
+	}
+	j.save()
+}
+
+// Count returns how many actions are in state.
+func (j *Journal) Count(state State) int {
+	count := 0
+	for _, action := range j.Actions {
+		if action.State == state {
+			count++
+		}
+	}
+	return count
+}
+
+func (j *Journal) save() {
+	if j.path == "" {
+		return
+	}
+	content, err := json.MarshalIndent(j.Actions, "", "  ")
+	if err == nil {
+		err = config.WriteCacheFile(j.path, content)
+	}
+	if err != nil {
+		log.Error("Failed saving journal", "path", j.path, "err", err)
+	}
+}
+
+// journalPath returns where the journal lives, next to the cached sections.
+// Actions on mocked data are never saved.
+func journalPath() (string, bool) {
+	if data.IsClientOverride() || config.IsFeatureEnabled(config.FF_MOCK_DATA) {
+		return "", false
+	}
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(cacheDir, journalFileName), true
+}
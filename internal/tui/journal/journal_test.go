@@ -0,0 +1,37 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/domain"
+)
+
+func TestJournalKeepsQueuedActions(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	j := Load()
+	key := domain.NewWorkItemKey("github:github.com", "owner/repo", 1, domain.WorkItemIssue)
+	comment := j.Add(Action{Kind: KindComment, Key: key, Repo: "owner/repo", Body: "hi", ItemUpdatedAt: time.Now()})
+	closed := j.Add(Action{Kind: KindClose, Key: key, Repo: "owner/repo"})
+	label := j.Add(Action{Kind: KindLabel, Key: key, Repo: "owner/repo", Values: []string{"bug"}})
+	if comment.Id == closed.Id || comment.State != StateQueued {
+		t.Fatalf("unexpected queued actions %+v %+v", comment, closed)
+	}
+
+	j.Resolve(comment.Id, StateSynced, nil)
+	j.Resolve(closed.Id, StateConflict, errors.New("changed"))
+	if j.Count(StateQueued) != 1 || j.Count(StateSynced) != 1 || j.Count(StateConflict) != 1 {
+		t.Fatalf("unexpected counts in %+v", j.Actions)
+	}
+
+	reloaded := Load()
+	queued := reloaded.Queued()
+	if len(queued) != 1 || queued[0].Id != label.Id || queued[0].Values[0] != "bug" {
+		t.Fatalf("expected only the label action to be kept, got %+v", reloaded.Actions)
+	}
+	if next := reloaded.Add(Action{Kind: KindComment, Key: key}); next.Id <= label.Id {
+		t.Fatalf("expected a new id after %d, got %d", label.Id, next.Id)
+	}
+}
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	log "github.com/charmbracelet/log"
+
+	"github.com/dlvhdr/gh-dash/v4/internal/data"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
+)
+
+// offlineProbeInterval is how often the hosts are checked while offline.
+const offlineProbeInterval = 30 * time.Second
+
+// connectivityMsg is the result of checking whether the hosts can be
+// reached. Only the checks scheduled while offline have scheduled set, so
+// a single one of them is pending at a time.
+type connectivityMsg struct {
+	online    bool
+	scheduled bool
+}
+
+// checkConnectivity checks after delay whether the hosts the dashboard
+// talks to can be reached. The hosts of queued actions all have to be, so
+// they can be sent, otherwise any provider's will do.
+func (m *Model) checkConnectivity(delay time.Duration, scheduled bool) tea.Cmd {
+	hosts, all := m.connectivityHosts()
+	check := func() tea.Msg {
+		reachable := 0
+		for _, host := range hosts {
+			if data.IsReachable(host) {
+				reachable++
+			}
+		}
+		online := reachable > 0
+		if all {
+			online = reachable == len(hosts)
+		}
+		return connectivityMsg{online: online, scheduled: scheduled}
+	}
+	if delay == 0 {
+		return check
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return check()
+	})
+}
+
+func (m *Model) connectivityHosts() ([]string, bool) {
+	seen := map[string]bool{}
+	var hosts []string
+	add := func(host string) {
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	for _, action := range m.ctx.Journal.Queued() {
+		provider, _ := m.ctx.ProviderByID(action.Key.ProviderID)
+		add(provider.Host)
+	}
+	if len(hosts) > 0 {
+		return hosts, true
+	}
+	for _, provider := range m.ctx.Providers {
+		add(provider.Host)
+	}
+	if len(hosts) == 0 {
+		add("")
+	}
+	return hosts, false
+}
+
+// goOffline keeps the sections on their cached rows and queues write
+// actions until the hosts can be reached again.
+func (m *Model) goOffline() tea.Cmd {
+	if !m.ctx.Offline {
+		log.Info("Hosts unreachable, going offline")
+	}
+	m.ctx.Offline = true
+	if m.probeScheduled {
+		return nil
+	}
+	m.probeScheduled = true
+	return m.checkConnectivity(offlineProbeInterval, true)
+}
+
+func (m *Model) onConnectivity(msg connectivityMsg) tea.Cmd {
+	if msg.scheduled {
+		m.probeScheduled = false
+	}
+	if !msg.online {
+		return m.goOffline()
+	}
+
+	var cmds []tea.Cmd
+	if m.ctx.Offline {
+		log.Info("Hosts reachable again, going online")
+		m.ctx.Offline = false
+		cmds = append(cmds, m.refreshAllViewSections())
+	}
+	cmds = append(cmds, m.replayJournal())
+	return tea.Batch(cmds...)
+}
+
+// onActionQueued journals an action taken while offline and shows it as if
+// it had been sent.
+func (m *Model) onActionQueued(msg tasks.ActionQueuedMsg) tea.Cmd {
+	action := m.ctx.Journal.Add(msg.Action)
+	log.Info("Queued action", "id", action.Id, "kind", action.Kind, "key", action.Key)
+	if task, ok := m.tasks[msg.TaskId]; ok {
+		task.FinishedText = fmt.Sprintf("%s (queued until online)", task.StartText)
+		m.tasks[msg.TaskId] = task
+	}
+
+	cmds := []tea.Cmd{
+		m.finishTask(msg.TaskId, nil),
+		m.updateSection(msg.SectionId, msg.SectionType, msg.Msg),
+	}
+	m.syncSectionsFromStore()
+	m.syncFilterValues()
+	cmds = append(cmds, m.syncSidebar(), m.goOffline())
+	return tea.Batch(cmds...)
+}
+
+// replayJournal sends the queued actions, unless they are being sent
+// already.
+func (m *Model) replayJournal() tea.Cmd {
+	queued := m.ctx.Journal.Queued()
+	if len(queued) == 0 || m.replaying {
+		return nil
+	}
+	m.replaying = true
+	task := context.Task{
+		Id:           fmt.Sprintf("journal_replay_%d", time.Now().Unix()),
+		StartText:    fmt.Sprintf("Sending %d queued actions", len(queued)),
+		FinishedText: "Sent the queued actions",
+		State:        context.TaskStart,
+		Error:        nil,
+	}
+	startCmd := m.ctx.StartTask(task)
+	return tea.Batch(startCmd, tasks.ReplayActions(m.ctx, task.Id, queued))
+}
+
+// onActionsReplayed records how sending the queued actions went and reports
+// the ones that conflicted or failed.
+func (m *Model) onActionsReplayed(msg tasks.ActionsReplayedMsg) tea.Cmd {
+	m.replaying = false
+	synced := 0
+	var problems []string
+	for _, result := range msg.Results {
+		m.ctx.Journal.Resolve(result.Action.Id, result.State, result.Err)
+		action := result.Action
+		switch result.State {
+		case journal.StateSynced:
+			synced++
+		case journal.StateConflict:
+			problems = append(problems, fmt.Sprintf("%s on %s#%d: changed since it was queued", action.Kind, action.Repo, action.Key.Number))
+		case journal.StateFailed:
+			problems = append(problems, fmt.Sprintf("%s on %s#%d: %v", action.Kind, action.Repo, action.Key.Number, result.Err))
+		}
+	}
+
+	var err error
+	if len(problems) > 0 {
+		err = fmt.Errorf("%d of %d queued actions not sent: %s", len(problems), len(msg.Results), strings.Join(problems, "; "))
+	}
+	if task, ok := m.tasks[msg.TaskId]; ok {
+		task.FinishedText = fmt.Sprintf("Sent %d queued actions", synced)
+		m.tasks[msg.TaskId] = task
+	}
+
+	cmds := []tea.Cmd{m.finishTask(msg.TaskId, err)}
+	if msg.Offline {
+		cmds = append(cmds, m.goOffline())
+	} else if len(msg.Results) > 0 {
+		cmds = append(cmds, m.refreshAllViewSections())
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderJournalStatus shows whether the dashboard is offline and how many
+// actions are queued and were synced since.
+func (m *Model) renderJournalStatus() string {
+	var parts []string
+	if m.ctx.Offline {
+		parts = append(parts, "offline")
+	}
+	if m.ctx.Journal != nil {
+		if queued := m.ctx.Journal.Count(journal.StateQueued); queued > 0 {
+			parts = append(parts, fmt.Sprintf("%d queued", queued))
+		}
+		if synced := m.ctx.Journal.Count(journal.StateSynced); synced > 0 {
+			parts = append(parts, fmt.Sprintf("%d synced", synced))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	color := m.ctx.Theme.FaintText
+	if m.ctx.Offline {
+		color = m.ctx.Theme.WarningText
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Background(m.ctx.Theme.SelectedBackground).
+		Render(fmt.Sprintf("[%s] ", strings.Join(parts, " • ")))
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -35,8 +36,10 @@ import (
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/section"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/sidebar"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tabs"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/components/tasks"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/constants"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/context"
+	"github.com/dlvhdr/gh-dash/v4/internal/tui/journal"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/keys"
 	"github.com/dlvhdr/gh-dash/v4/internal/tui/theme"
 )
@@ -56,6 +59,10 @@ type Model struct {
 	ctx           *context.ProgramContext
 	taskSpinner   spinner.Model
 	tasks         map[string]context.Task
+	// probeScheduled is set while a connectivity check is pending offline,
+	// and replaying while the queued actions are being sent.
+	probeScheduled bool
+	replaying      bool
 }
 
 func NewModel(location config.Location) Model {
@@ -76,6 +83,8 @@ func NewModel(location config.Location) Model {
 		RepoPath:   location.RepoPath,
 		ConfigFlag: location.ConfigFlag,
 		Version:    version,
+		Store:      domain.NewStore(),
+		Journal:    journal.Load(),
 		StartTask: func(task context.Task) tea.Cmd {
 			log.Info("Starting task", "id", task.Id)
 			task.StartTime = time.Now()
@@ -149,23 +158,7 @@ func (m *Model) initScreen() tea.Msg {
 		showError(err)
 	}
 
-	providersList := make([]providers.Instance, 0)
-	if ghProviders, err := providers.DiscoverGitHubInstances(); err == nil {
-		providersList = append(providersList, ghProviders...)
-	} else {
-		log.Warn("failed to discover GitHub hosts", "err", err)
-	}
-	if glProviders, err := providers.DiscoverGitLabInstances(); err == nil {
-		providersList = append(providersList, glProviders...)
-	} else {
-		log.Warn("failed to discover GitLab hosts", "err", err)
-	}
-
-	providersList = providers.FilterInstances(
-		providersList,
-		cfg.Providers.Include,
-		cfg.Providers.Exclude,
-	)
+	providersList := providers.DiscoverInstances(cfg.Providers.Include, cfg.Providers.Exclude)
 
 	return initMsg{Config: cfg, RepoUrl: url, Providers: providersList}
 }
@@ -264,6 +257,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sidebar.IsOpen = !m.sidebar.IsOpen
 			m.syncMainContentWidth()
 
+		case key.Matches(msg, m.keys.Refresh) && m.ctx.Offline,
+			key.Matches(msg, m.keys.RefreshAll) && m.ctx.Offline:
+			// keep the cached rows, the sections are refreshed once the
+			// hosts can be reached
+			cmds = append(cmds, m.checkConnectivity(0, false))
+
 		case key.Matches(msg, m.keys.Refresh):
 			currSection.ResetFilters()
 			currSection.ResetRows()
@@ -573,45 +572,54 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.tabs.SetCurrSectionId(1)
 		cmds = append(cmds, fetchSectionsCmds, m.tabs.Init(), fetchUser,
 			m.doRefreshAtInterval(), m.doUpdateFooterAtInterval())
+		if len(m.ctx.Journal.Queued()) > 0 {
+			cmds = append(cmds, m.checkConnectivity(0, false))
+		}
 
 	case intervalRefresh:
-		newSections, fetchSectionsCmds := m.fetchAllViewSections()
-		m.setCurrentViewSections(newSections)
-		cmds = append(cmds, fetchSectionsCmds, m.doRefreshAtInterval())
+		if !m.ctx.Offline {
+			cmds = append(cmds, m.refreshAllViewSections())
+		}
+		cmds = append(cmds, m.doRefreshAtInterval())
 
 	case userFetchedMsg:
 		m.ctx.User = msg.user
 
 	case constants.TaskFinishedMsg:
-		task, ok := m.tasks[msg.TaskId]
-		if ok {
-			log.Info("Task finished", "id", task.Id)
-			if msg.Err != nil {
-				log.Error("Task finished with error", "id", task.Id, "err", msg.Err)
-				task.State = context.TaskError
-				task.Error = msg.Err
-			} else {
-				task.State = context.TaskFinished
+		if _, ok := m.tasks[msg.TaskId]; ok {
+			cmds = append(cmds, m.finishTask(msg.TaskId, msg.Err))
+			if data.IsNetworkWriteError(msg.Err) && !m.ctx.Offline {
+				// tell a failure from the network being down
+				cmds = append(cmds, m.checkConnectivity(0, false))
 			}
-			now := time.Now()
-			task.FinishedTime = &now
-			m.tasks[msg.TaskId] = task
-			clear := tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-				return constants.ClearTaskMsg{TaskId: msg.TaskId}
-			})
-			cmds = append(cmds, clear)
 
 			scmd := m.updateSection(msg.SectionId, msg.SectionType, msg.Msg)
 			cmds = append(cmds, scmd)
+			m.syncSectionsFromStore()
+			m.syncFilterValues()
 
 			syncCmd := m.syncSidebar()
 			cmds = append(cmds, syncCmd)
 		}
 
+	case tasks.ActionQueuedMsg:
+		cmds = append(cmds, m.onActionQueued(msg))
+		m.footer.SetRightSection(m.renderRunningTask())
+
+	case tasks.ActionsReplayedMsg:
+		cmds = append(cmds, m.onActionsReplayed(msg))
+		m.footer.SetRightSection(m.renderRunningTask())
+
+	case connectivityMsg:
+		cmds = append(cmds, m.onConnectivity(msg))
+		m.footer.SetRightSection(m.renderRunningTask())
+
 	case prview.EnrichedPrMsg:
+		m.prView.FinishEnrich(msg)
 		if msg.Err == nil {
 			m.prView.SetEnrichedPR(msg.Data)
-			m.prs[msg.Id].(*prssection.Model).EnrichPR(msg.Data)
+			m.ctx.Store.EnrichPullRequest(msg.Key, msg.Data)
+			m.syncSectionsFromStore()
 			syncCmd := m.syncSidebar()
 			cmds = append(cmds, syncCmd)
 		} else {
@@ -628,8 +636,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case constants.ClearTaskMsg:
-		m.footer.SetRightSection("")
 		delete(m.tasks, msg.TaskId)
+		m.footer.SetRightSection(m.renderRunningTask())
+
+	case section.FilterExplainedMsg:
+		m.sidebar.IsOpen = true
+		m.syncMainContentWidth()
+		m.sidebar.SetContent(lipgloss.NewStyle().Width(m.sidebar.GetSidebarContentWidth()).Render(msg.Text))
+		m.sidebar.ScrollToTop()
 
 	case section.SectionMsg:
 		cmd = m.updateRelevantSection(msg)
@@ -639,7 +653,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case execProcessFinishedMsg, tea.FocusMsg:
-		if currSection != nil {
+		if currSection != nil && !m.ctx.Offline {
 			cmds = append(cmds, currSection.FetchNextPageSectionRows()...)
 		}
 
@@ -772,6 +786,9 @@ func (m *Model) onViewedRowChanged() tea.Cmd {
 	m.updateActiveKeyHelp()
 	m.syncSidebar()
 	cmd := m.prView.EnrichCurrRow()
+	if prs, ok := m.getCurrSection().(*prssection.Model); ok {
+		cmd = tea.Batch(cmd, m.prView.PrefetchRows(prs.Prs, prs.CurrRow()))
+	}
 	m.sidebar.ScrollToTop()
 	return cmd
 }
@@ -870,6 +887,36 @@ func (m *Model) syncMainContentWidth() {
 	m.ctx.MainContentWidth = m.ctx.ScreenWidth - sideBarOffset
 }
 
+// syncSectionsFromStore shows the latest stored version of each item in
+// every section, after a fetch or an action changed it in one of them.
+func (m *Model) syncSectionsFromStore() {
+	for _, s := range m.prs {
+		if prs, ok := s.(*prssection.Model); ok {
+			prs.SyncFromStore()
+		}
+	}
+	for _, s := range m.issues {
+		if issues, ok := s.(*issuessection.Model); ok {
+			issues.SyncFromStore()
+		}
+	}
+}
+
+func (m *Model) syncFilterValues() {
+	seen := map[string]map[string]bool{}
+	for _, s := range m.prs {
+		if prs, ok := s.(*prssection.Model); ok {
+			section.AddFilterValues(seen, prs.Prs)
+		}
+	}
+	for _, s := range m.issues {
+		if issues, ok := s.(*issuessection.Model); ok {
+			section.AddFilterValues(seen, issues.Issues)
+		}
+	}
+	m.ctx.FilterValues = section.FilterValues(seen, m.ctx.Providers)
+}
+
 func (m *Model) syncSidebar() tea.Cmd {
 	currRowData := m.getCurrRowData()
 	width := m.sidebar.GetSidebarContentWidth()
@@ -915,12 +962,30 @@ func (m *Model) fetchAllViewSections() ([]section.Section, tea.Cmd) {
 		cmds = append(cmds, prcmds)
 		return s, tea.Batch(cmds...)
 	default:
-		s, issuecmds := issuessection.FetchAllSections(m.ctx)
+		s, issuecmds := issuessection.FetchAllSections(m.ctx, m.issues)
 		cmds = append(cmds, issuecmds)
 		return s, tea.Batch(cmds...)
 	}
 }
 
+// refreshAllViewSections updates the sections of the current view with what
+// changed since they were fetched, and fetches those that can't be refreshed
+// again.
+func (m *Model) refreshAllViewSections() tea.Cmd {
+	if m.ctx.View == config.RepoView {
+		newSections, fetchSectionsCmds := m.fetchAllViewSections()
+		m.setCurrentViewSections(newSections)
+		return fetchSectionsCmds
+	}
+	var cmds []tea.Cmd
+	for _, s := range m.getCurrentViewSections() {
+		if refresher, ok := s.(section.Refresher); ok {
+			cmds = append(cmds, refresher.RefreshSectionRows()...)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *Model) getCurrentViewSections() []section.Section {
 	switch m.ctx.View {
 	case config.RepoView:
@@ -1053,7 +1118,45 @@ func (m *Model) isUserDefinedKeybinding(msg tea.KeyMsg) bool {
 	return false
 }
 
+// finishTask marks the task as done and clears it from the footer a bit
+// later.
+func (m *Model) finishTask(taskId string, err error) tea.Cmd {
+	task, ok := m.tasks[taskId]
+	if !ok {
+		return nil
+	}
+	log.Info("Task finished", "id", task.Id)
+	if err != nil {
+		log.Error("Task finished with error", "id", task.Id, "err", err)
+		if cause := errors.Unwrap(err); cause != nil {
+			log.Error("Task error cause", "id", task.Id, "cause", cause)
+		}
+		task.State = context.TaskError
+		task.Error = err
+	} else {
+		task.State = context.TaskFinished
+	}
+	now := time.Now()
+	task.FinishedTime = &now
+	m.tasks[taskId] = task
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return constants.ClearTaskMsg{TaskId: taskId}
+	})
+}
+
 func (m *Model) renderRunningTask() string {
+	journalStatus := m.renderJournalStatus()
+	if len(m.tasks) == 0 {
+		if journalStatus == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().
+			Padding(0, 1).
+			Height(1).
+			Background(m.ctx.Theme.SelectedBackground).
+			Render(strings.TrimSpace(journalStatus))
+	}
+
 	tasks := make([]context.Task, 0, len(m.tasks))
 	for _, value := range m.tasks {
 		tasks = append(tasks, value)
@@ -1085,8 +1188,13 @@ func (m *Model) renderRunningTask() string {
 					task.StartText,
 				))
 	case context.TaskError:
+		// errors that may go away on their own are only warnings
+		color := m.ctx.Theme.ErrorText
+		if data.IsRetryableWriteError(task.Error) {
+			color = m.ctx.Theme.WarningText
+		}
 		currTaskStatus = lipgloss.NewStyle().
-			Foreground(m.ctx.Theme.ErrorText).
+			Foreground(color).
 			Background(m.ctx.Theme.SelectedBackground).
 			Render(fmt.Sprintf("%s %s", constants.FailureIcon, task.Error.Error()))
 	case context.TaskFinished:
@@ -1115,7 +1223,7 @@ func (m *Model) renderRunningTask() string {
 		Padding(0, 1).
 		Height(1).
 		Background(m.ctx.Theme.SelectedBackground).
-		Render(strings.TrimSpace(lipgloss.JoinHorizontal(lipgloss.Top, stats, currTaskStatus)))
+		Render(strings.TrimSpace(lipgloss.JoinHorizontal(lipgloss.Top, journalStatus, stats, currTaskStatus)))
 }
 
 type userFetchedMsg struct {